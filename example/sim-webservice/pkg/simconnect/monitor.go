@@ -156,11 +156,17 @@ func (mc *MonitorClient) Connect() error {
 	fmt.Println("🔗 Connecting to Microsoft Flight Simulator...")
 
 	// Create new SimConnect client with custom DLL path if provided
+	var conn client.Connection
+	var err error
 	if mc.dllPath != "" {
-		mc.sdk = client.NewWithCustomDLL("SimWebService", mc.dllPath).(*client.Engine)
+		conn, err = client.LoadWithCustomDLL("SimWebService", mc.dllPath)
 	} else {
-		mc.sdk = client.New("SimWebService").(*client.Engine)
+		conn, err = client.Load("SimWebService")
 	}
+	if err != nil {
+		return fmt.Errorf("failed to load SimConnect: %v", err)
+	}
+	mc.sdk = conn.(*client.Engine)
 
 	// Connect to SimConnect
 	if err := mc.sdk.Open(); err != nil {
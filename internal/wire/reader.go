@@ -0,0 +1,147 @@
+package wire
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Reader decodes little-endian, byte-packed SimConnect payloads.
+// SimConnect.h is compiled with #pragma pack(1), so nested records cannot be
+// cast to Go structs safely and are read field by field instead.
+type Reader struct {
+	buf []byte
+	off int
+	err bool
+}
+
+// NewReader creates a reader over a copied SimConnect payload
+func NewReader(buf []byte) *Reader {
+	return &Reader{buf: buf}
+}
+
+// take returns the next n bytes, or nil when the payload is too short
+func (r *Reader) take(n int) []byte {
+	if r.err || n < 0 || r.off+n > len(r.buf) {
+		r.err = true
+		return nil
+	}
+	b := r.buf[r.off : r.off+n]
+	r.off += n
+	return b
+}
+
+// Uint8 reads a single byte
+func (r *Reader) Uint8() uint8 {
+	if b := r.take(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+// Uint16 reads a 16-bit unsigned integer
+func (r *Reader) Uint16() uint16 {
+	if b := r.take(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+// Uint32 reads a 32-bit unsigned integer (DWORD)
+func (r *Reader) Uint32() uint32 {
+	if b := r.take(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+// Int32 reads a 32-bit signed integer
+func (r *Reader) Int32() int32 {
+	return int32(r.Uint32())
+}
+
+// Uint64 reads a 64-bit unsigned integer
+func (r *Reader) Uint64() uint64 {
+	if b := r.take(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
+
+// Int64 reads a 64-bit signed integer
+func (r *Reader) Int64() int64 {
+	return int64(r.Uint64())
+}
+
+// Float32 reads a 32-bit IEEE float
+func (r *Reader) Float32() float32 {
+	return math.Float32frombits(r.Uint32())
+}
+
+// Float64 reads a 64-bit IEEE float
+func (r *Reader) Float64() float64 {
+	return math.Float64frombits(r.Uint64())
+}
+
+// String reads a fixed-size, null-terminated character array
+func (r *Reader) String(size int) string {
+	b := r.take(size)
+	if b == nil {
+		return ""
+	}
+	return CString(b)
+}
+
+// Bytes reads n raw bytes (copied)
+func (r *Reader) Bytes(n int) []byte {
+	b := r.take(n)
+	if b == nil {
+		return nil
+	}
+	out := make([]byte, n)
+	copy(out, b)
+	return out
+}
+
+// Rest returns a copy of the unread remainder of the payload
+func (r *Reader) Rest() []byte {
+	if r.err || r.off >= len(r.buf) {
+		return nil
+	}
+	out := make([]byte, len(r.buf)-r.off)
+	copy(out, r.buf[r.off:])
+	r.off = len(r.buf)
+	return out
+}
+
+// Skip advances the reader by n bytes
+func (r *Reader) Skip(n int) {
+	r.take(n)
+}
+
+// Offset returns the current read position
+func (r *Reader) Offset() int {
+	return r.off
+}
+
+// Remaining returns the number of unread bytes
+func (r *Reader) Remaining() int {
+	if r.err {
+		return 0
+	}
+	return len(r.buf) - r.off
+}
+
+// Err reports whether a read ran past the end of the payload
+func (r *Reader) Err() bool {
+	return r.err
+}
+
+// CString converts a null-terminated byte array to a Go string
+func CString(b []byte) string {
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// Errors reported by ExecuteAction for the action exceptions, matched with errors.Is
+var (
+	ErrActionNotFound        = errors.New("action not found")
+	ErrNotAnAction           = errors.New("not an action")
+	ErrIncorrectActionParams = errors.New("incorrect action parameters")
+)
+
+// ActionError is returned by ExecuteAction when the simulator rejects an action with an exception
+type ActionError struct {
+	ActionID  string
+	Exception *types.ExceptionData
+}
+
+func (err *ActionError) Error() string {
+	return fmt.Sprintf("action %s failed: %s (%s)", err.ActionID, err.Exception.ExceptionName, err.Exception.Description)
+}
+
+// Unwrap returns the exception code, plus ErrActionNotFound, ErrNotAnAction or ErrIncorrectActionParams
+// for the action exceptions
+func (err *ActionError) Unwrap() []error {
+	code := err.Exception.ExceptionCode
+	switch code {
+	case types.SIMCONNECT_EXCEPTION_ACTION_NOT_FOUND:
+		return []error{ErrActionNotFound, code}
+	case types.SIMCONNECT_EXCEPTION_NOT_AN_ACTION:
+		return []error{ErrNotAnAction, code}
+	case types.SIMCONNECT_EXCEPTION_INCORRECT_ACTION_PARAMS:
+		return []error{ErrIncorrectActionParams, code}
+	}
+	return []error{code}
+}
+
+// pendingAction is an ExecuteAction call waiting for its callback
+type pendingAction struct {
+	requestID uint32
+	actionID  string
+}
+
+// ExecuteAction runs a simulator action (MSFS 2024) and waits for its ACTION_CALLBACK; Listen() must be active.
+// params holds the packed parameter values the action expects (little-endian, in declaration order),
+// or nil when it takes none. Rejected actions fail with an *ActionError.
+func (e *Engine) ExecuteAction(ctx context.Context, actionID string, params []byte) (*types.ActionCallback, error) {
+	if err := e.ensureListening(); err != nil {
+		return nil, err
+	}
+
+	actionPtr, err := syscall.BytePtrFromString(actionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid action ID: %v", err)
+	}
+
+	var paramsPtr unsafe.Pointer
+	if len(params) > 0 {
+		paramsPtr = unsafe.Pointer(&params[0])
+	}
+
+	requestID := e.nextInternalID()
+	key := pendingKey{kind: "action", id: requestID}
+	ch := e.expect(key)
+
+	// Get handle (thread-safe)
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	// Register the packet before an exception for it can be dispatched (see failAction)
+	e.actionMu.Lock()
+	// Call SimConnect_ExecuteAction
+	hresult, sendID := e.call(SimConnect_ExecuteAction, []any{requestID, actionID, len(params)},
+		uintptr(handle),                    // hSimConnect
+		uintptr(requestID),                 // cbRequestID
+		uintptr(unsafe.Pointer(actionPtr)), // szActionID
+		uintptr(len(params)),               // cbUnitSize
+		uintptr(paramsPtr),                 // pParamValues
+	)
+	// Without a packet ID the action still completes, only exceptions cannot be tied to it
+	tracked := sendID != 0
+	if tracked {
+		e.mu.Lock()
+		e.actionPackets[sendID] = pendingAction{requestID: requestID, actionID: actionID}
+		e.mu.Unlock()
+	}
+	e.actionMu.Unlock()
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		e.forget(key)
+		return nil, &HRESULTError{Function: "SimConnect_ExecuteAction", Code: uint32(hresult)}
+	}
+	if tracked {
+		defer func() {
+			e.mu.Lock()
+			delete(e.actionPackets, sendID)
+			e.mu.Unlock()
+		}()
+	}
+
+	value, err := e.await(ctx, key, ch)
+	if err != nil {
+		return nil, err
+	}
+	return value.(*types.ActionCallback), nil
+}
+
+// failAction fails a waiting ExecuteAction whose packet raised an exception
+func (e *Engine) failAction(exception *types.ExceptionData) {
+	e.actionMu.Lock()
+	defer e.actionMu.Unlock()
+
+	e.mu.RLock()
+	action, exists := e.actionPackets[exception.SendID]
+	e.mu.RUnlock()
+
+	if exists {
+		e.resolve(pendingKey{kind: "action", id: action.requestID}, &ActionError{ActionID: action.actionID, Exception: exception})
+	}
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"sort"
+	"syscall"
+)
+
+// requiredExports are needed for any connection; a DLL without them is rejected
+var requiredExports = []string{
+	"SimConnect_Open",
+	"SimConnect_Close",
+	"SimConnect_GetNextDispatch",
+	"SimConnect_GetLastSentPacketID",
+}
+
+// msfs2024Exports are only provided by the MSFS 2024 SimConnect DLL
+var msfs2024Exports = []string{
+	"SimConnect_ExecuteAction",
+	"SimConnect_RequestJetwayData",
+	"SimConnect_EnumerateSimObjectsAndLiveries",
+	"SimConnect_SubscribeToFlowEvent",
+	"SimConnect_UnsubscribeToFlowEvent",
+}
+
+// Capabilities reports which SimConnect exports the loaded DLL provides.
+// Calls whose export is missing fail with an *HRESULTError carrying E_NOTIMPL.
+type Capabilities struct {
+	DLLPath  string   `json:"dll_path"`
+	Present  []string `json:"present"`  // Exports found, sorted
+	Missing  []string `json:"missing"`  // Exports the SDK uses but the DLL lacks, sorted
+	MSFS2024 bool     `json:"msfs2024"` // Every MSFS 2024-only export is present
+}
+
+// Supports reports whether the DLL provides an export, e.g. "SimConnect_ExecuteAction"
+func (c Capabilities) Supports(export string) bool {
+	i := sort.SearchStrings(c.Present, export)
+	return i < len(c.Present) && c.Present[i] == export
+}
+
+// missingRequired returns the required exports the DLL lacks
+func (c Capabilities) missingRequired() []string {
+	var missing []string
+	for _, export := range requiredExports {
+		if !c.Supports(export) {
+			missing = append(missing, export)
+		}
+	}
+	return missing
+}
+
+// probeCapabilities resolves every procedure of a loaded DLL
+func probeCapabilities(path string, procedures []*syscall.LazyProc) Capabilities {
+	capabilities := Capabilities{DLLPath: path}
+	for _, proc := range procedures {
+		if proc.Find() == nil {
+			capabilities.Present = append(capabilities.Present, proc.Name)
+		} else {
+			capabilities.Missing = append(capabilities.Missing, proc.Name)
+		}
+	}
+	sort.Strings(capabilities.Present)
+	sort.Strings(capabilities.Missing)
+
+	capabilities.MSFS2024 = true
+	for _, export := range msfs2024Exports {
+		if !capabilities.Supports(export) {
+			capabilities.MSFS2024 = false
+			break
+		}
+	}
+	return capabilities
+}
+
+// Capabilities returns the exports of the SimConnect DLL; empty when it could not be loaded
+func (e *Engine) Capabilities() Capabilities {
+	capabilities := e.capabilities
+	capabilities.Present = append([]string(nil), capabilities.Present...)
+	capabilities.Missing = append([]string(nil), capabilities.Missing...)
+	return capabilities
+}
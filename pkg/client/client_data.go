@@ -0,0 +1,282 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"syscall"
+	"unsafe"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// MapClientDataNameToID associates a client data area name with a client data ID
+// Every client that shares the area, including WASM modules, maps the same name
+func (e *Engine) MapClientDataNameToID(name string, clientDataID uint32) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	namePtr, err := syscall.BytePtrFromString(name)
+	if err != nil {
+		return fmt.Errorf("invalid client data name: %v", err)
+	}
+
+	// Get handle (thread-safe)
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	// Call SimConnect_MapClientDataNameToID
+	hresult, _ := e.call(SimConnect_MapClientDataNameToID, []any{name, clientDataID},
+		uintptr(handle),                  // hSimConnect
+		uintptr(unsafe.Pointer(namePtr)), // szClientDataName
+		uintptr(clientDataID),            // ClientDataID
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: "SimConnect_MapClientDataNameToID", Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// CreateClientData creates a client data area of size bytes (max 8192)
+// flags is SIMCONNECT_CREATE_CLIENT_DATA_FLAG_DEFAULT or SIMCONNECT_CREATE_CLIENT_DATA_FLAG_READ_ONLY
+func (e *Engine) CreateClientData(clientDataID uint32, size uint32, flags uint32) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	// Get handle (thread-safe)
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	// Call SimConnect_CreateClientData
+	hresult, _ := e.call(SimConnect_CreateClientData, []any{clientDataID, size, flags},
+		uintptr(handle),       // hSimConnect
+		uintptr(clientDataID), // ClientDataID
+		uintptr(size),         // dwSize
+		uintptr(flags),        // Flags
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: "SimConnect_CreateClientData", Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// AddToClientDataDefinition adds an entry to a client data definition
+// offset may be SIMCONNECT_CLIENTDATAOFFSET_AUTO; sizeOrType is a byte size or a SIMCONNECT_CLIENTDATATYPE value
+func (e *Engine) AddToClientDataDefinition(defID uint32, offset uint32, sizeOrType types.ClientDataType, epsilon float32, datumID uint32) error {
+	return e.addToClientDataDefinition(defID, offset, sizeOrType, epsilon, datumID, "")
+}
+
+// addToClientDataDefinition adds an entry, recording its field name for exceptions when known
+func (e *Engine) addToClientDataDefinition(defID uint32, offset uint32, sizeOrType types.ClientDataType, epsilon float32, datumID uint32, fieldName string) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	// Get handle (thread-safe)
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	// Call SimConnect_AddToClientDataDefinition
+	hresult, _ := e.callField(SimConnect_AddToClientDataDefinition, fieldName, []any{defID, offset, sizeOrType, epsilon, datumID},
+		uintptr(handle),                    // hSimConnect
+		uintptr(defID),                     // DefineID
+		uintptr(offset),                    // dwOffset
+		uintptr(uint32(sizeOrType)),        // dwSizeOrType
+		uintptr(math.Float32bits(epsilon)), // fEpsilon (passed on the stack)
+		uintptr(datumID),                   // DatumID
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: "SimConnect_AddToClientDataDefinition", Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// ClearClientDataDefinition removes all entries from a client data definition
+func (e *Engine) ClearClientDataDefinition(defID uint32) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	// Get handle (thread-safe)
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	// Call SimConnect_ClearClientDataDefinition
+	hresult, _ := e.call(SimConnect_ClearClientDataDefinition, []any{defID},
+		uintptr(handle), // hSimConnect
+		uintptr(defID),  // DefineID
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: "SimConnect_ClearClientDataDefinition", Code: uint32(hresult)}
+	}
+
+	e.mu.Lock()
+	delete(e.clientDataDefinitions, defID)
+	e.mu.Unlock()
+	return nil
+}
+
+// RegisterClientDataDefinition sends every entry of a client data definition and remembers its layout,
+// so CLIENT_DATA messages for this DefineID carry decoded Values and SetClientDataValues can encode
+func (e *Engine) RegisterClientDataDefinition(defID uint32, def *types.ClientDataDefinition) error {
+	fields, err := def.Fields()
+	if err != nil {
+		return fmt.Errorf("invalid client data definition: %w", err)
+	}
+
+	// The entry index is its DatumID, which identifies entries in tagged data
+	for i, f := range fields {
+		if err := e.addToClientDataDefinition(defID, f.Offset, f.Type, f.Epsilon, uint32(i), f.Name); err != nil {
+			return err
+		}
+	}
+
+	// Store the layout for decoding (thread-safe)
+	e.mu.Lock()
+	e.clientDataDefinitions[defID] = def
+	e.mu.Unlock()
+
+	return nil
+}
+
+// SetClientData writes a data block laid out by a client data definition into a client data area
+func (e *Engine) SetClientData(clientDataID uint32, defID uint32, flags uint32, data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("client data is empty")
+	}
+
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	// Get handle (thread-safe)
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	// Call SimConnect_SetClientData
+	hresult, _ := e.call(SimConnect_SetClientData, []any{clientDataID, defID, flags, len(data)},
+		uintptr(handle),                   // hSimConnect
+		uintptr(clientDataID),             // ClientDataID
+		uintptr(defID),                    // DefineID
+		uintptr(flags),                    // Flags
+		0,                                 // dwReserved
+		uintptr(len(data)),                // cbUnitSize
+		uintptr(unsafe.Pointer(&data[0])), // pDataSet
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: "SimConnect_SetClientData", Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// SetClientDataValues encodes name → value pairs with a registered definition and writes them
+func (e *Engine) SetClientDataValues(clientDataID uint32, defID uint32, values map[string]any) error {
+	e.mu.RLock()
+	def, exists := e.clientDataDefinitions[defID]
+	e.mu.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("%w: client data definition %d not registered - call RegisterClientDataDefinition first", ErrUnknownDefinition, defID)
+	}
+
+	data, err := def.Encode(values)
+	if err != nil {
+		return err
+	}
+	return e.SetClientData(clientDataID, defID, types.SIMCONNECT_CLIENT_DATA_SET_FLAG_DEFAULT, data)
+}
+
+// RequestClientData requests the contents of a client data area; data arrives as "client_data" messages
+// flags combines SIMCONNECT_CLIENT_DATA_REQUEST_FLAG_CHANGED and SIMCONNECT_CLIENT_DATA_REQUEST_FLAG_TAGGED
+func (e *Engine) RequestClientData(clientDataID uint32, requestID uint32, defID uint32, period types.ClientDataPeriod, flags uint32) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	// Get handle (thread-safe)
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	// Call SimConnect_RequestClientData
+	hresult, _ := e.call(SimConnect_RequestClientData, []any{clientDataID, requestID, defID, period, flags},
+		uintptr(handle),       // hSimConnect
+		uintptr(clientDataID), // ClientDataID
+		uintptr(requestID),    // RequestID
+		uintptr(defID),        // DefineID
+		uintptr(period),       // Period
+		uintptr(flags),        // Flags
+		0,                     // origin
+		0,                     // interval
+		0,                     // limit
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: "SimConnect_RequestClientData", Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// GetClientData reads a client data area once and waits for the reply; Listen() must be active
+func (e *Engine) GetClientData(ctx context.Context, clientDataID uint32, defID uint32) (*types.ClientData, error) {
+	if err := e.ensureListening(); err != nil {
+		return nil, err
+	}
+
+	requestID := e.nextInternalID()
+	key := pendingKey{kind: "client_data", id: requestID}
+	ch := e.expect(key)
+
+	if err := e.RequestClientData(clientDataID, requestID, defID, types.SIMCONNECT_CLIENT_DATA_PERIOD_ONCE, types.SIMCONNECT_CLIENT_DATA_REQUEST_FLAG_DEFAULT); err != nil {
+		e.forget(key)
+		return nil, err
+	}
+
+	value, err := e.await(ctx, key, ch)
+	if err != nil {
+		return nil, err
+	}
+	return value.(*types.ClientData), nil
+}
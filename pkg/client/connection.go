@@ -1,10 +1,12 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"syscall"
 	"unsafe"
 
+	"github.com/mycrew-online/sdk/pkg/facilities"
 	"github.com/mycrew-online/sdk/pkg/types"
 )
 
@@ -12,17 +14,106 @@ type Connection interface {
 	Open() error
 	Close() error
 	Listen() <-chan any
+	Capabilities() Capabilities
+	GetLastSentPacketID() (uint32, error)
+	PacketHistory() []types.PacketContext
 	RegisterSimVarDefinition(defID uint32, varName string, units string, dataType types.SimConnectDataType) error
 	RequestSimVarData(defID uint32, requestID uint32) error
 	RequestSimVarDataPeriodic(defID uint32, requestID uint32, period types.SimConnectPeriod) error
 	StopPeriodicRequest(requestID uint32) error
 	SetSimVar(defID uint32, value interface{}) error
+	RegisterSimVarDefinitionAndVerify(ctx context.Context, defID uint32, varName string, units string, dataType types.SimConnectDataType) error
+	RequestSimVarDataAndVerify(ctx context.Context, defID uint32, requestID uint32) error
+	RequestSimVarDataPeriodicAndVerify(ctx context.Context, defID uint32, requestID uint32, period types.SimConnectPeriod) error
+	SetSimVarAndVerify(ctx context.Context, defID uint32, value interface{}) error
 	SubscribeToSystemEvent(eventID uint32, eventName string) error
+	SubscribeSystemEvent(name types.SystemEventName) (uint32, error)
+	SetSystemEventState(eventID uint32, state types.SimConnectState) error
+	UnsubscribeFromSystemEvent(eventID uint32) error
+	SystemEventState(eventID uint32) (types.SystemEventStatus, bool)
+	SystemEventSubscriptions() []types.SystemEventSubscription
 	// Client Event Management
 	MapClientEventToSimEvent(eventID types.ClientEventID, eventName string) error
 	AddClientEventToNotificationGroup(groupID types.NotificationGroupID, eventID types.ClientEventID, maskable bool) error
 	SetNotificationGroupPriority(groupID types.NotificationGroupID, priority uint32) error
 	TransmitClientEvent(objectID uint32, eventID types.ClientEventID, data uint32, groupID types.NotificationGroupID, flags uint32) error
+	TransmitClientEvent_EX1(objectID uint32, eventID types.ClientEventID, groupID types.NotificationGroupID, flags uint32, data ...uint32) error
+	Send(ctx context.Context, event types.KEvent, args ...uint32) error
+	RemoveClientEvent(groupID types.NotificationGroupID, eventID types.ClientEventID) error
+	InterceptEvent(event types.KEvent, priority uint32, handler InterceptFunc) (*EventInterceptor, error)
+	RemoveInterceptor(ic *EventInterceptor) error
+	NameNotificationGroup(groupID types.NotificationGroupID, name string)
+	EventName(eventID uint32) (types.EventRegistration, bool)
+	EventRegistrations() []types.EventRegistration
+	// Input mapping
+	MapInputEventToClientEvent(groupID types.InputGroupID, input string, downEventID types.ClientEventID, downValue uint32, upEventID types.ClientEventID, upValue uint32, maskable bool) error
+	SetInputGroupPriority(groupID types.InputGroupID, priority uint32) error
+	SetInputGroupState(groupID types.InputGroupID, state types.SimConnectState) error
+	RemoveInputEvent(groupID types.InputGroupID, input string) error
+	ClearInputGroup(groupID types.InputGroupID) error
+	BindInput(groupID types.InputGroupID, input string) (*types.InputBinding, error)
+	Unbind(binding *types.InputBinding) error
+	InputBindings() []types.InputBinding
+	EnumerateControllers() error
+	Controllers(ctx context.Context) ([]types.ControllerItem, error)
+	// Client data
+	MapClientDataNameToID(name string, clientDataID uint32) error
+	CreateClientData(clientDataID uint32, size uint32, flags uint32) error
+	AddToClientDataDefinition(defID uint32, offset uint32, sizeOrType types.ClientDataType, epsilon float32, datumID uint32) error
+	ClearClientDataDefinition(defID uint32) error
+	RegisterClientDataDefinition(defID uint32, def *types.ClientDataDefinition) error
+	SetClientData(clientDataID uint32, defID uint32, flags uint32, data []byte) error
+	SetClientDataValues(clientDataID uint32, defID uint32, values map[string]any) error
+	RequestClientData(clientDataID uint32, requestID uint32, defID uint32, period types.ClientDataPeriod, flags uint32) error
+	GetClientData(ctx context.Context, clientDataID uint32, defID uint32) (*types.ClientData, error)
+	// Facilities
+	RegisterFacilityDefinition(defID uint32, def *facilities.Definition) error
+	RequestFacilityData(defID uint32, requestID uint32, icao string, region string) error
+	RequestFacility(ctx context.Context, defID uint32, icao string, region string) (*facilities.Node, error)
+	RequestFacilitiesList(listType types.FacilityListType, requestID uint32) error
+	RequestFacilitiesList_EX1(listType types.FacilityListType, requestID uint32) error
+	SubscribeToFacilities(listType types.FacilityListType, requestID uint32) error
+	SubscribeToFacilities_EX1(listType types.FacilityListType, newInRangeRequestID uint32, oldOutRangeRequestID uint32) error
+	UnsubscribeToFacilities(listType types.FacilityListType) error
+	UnsubscribeToFacilities_EX1(listType types.FacilityListType, unsubscribeNewInRange bool, unsubscribeOldOutRange bool) error
+	RequestFacilities(ctx context.Context, listType types.FacilityListType, minimal bool) (*types.FacilityListData, error)
+	// Input events
+	EnumerateInputEvents(requestID uint32) error
+	InputEvents(ctx context.Context) ([]types.InputEventDescriptor, error)
+	InputEventHash(name string) (uint64, bool)
+	RequestInputEvent(requestID uint32, hash uint64) error
+	GetInputEvent(ctx context.Context, hash uint64) (*types.InputEventValue, error)
+	SetInputEvent(hash uint64, value any) error
+	SubscribeInputEvent(hash uint64) error
+	UnsubscribeInputEvent(hash uint64) error
+	EnumerateInputEventParams(hash uint64) error
+	InputEventParams(ctx context.Context, hash uint64) (*types.InputEventParams, error)
+	// System state
+	RequestSystemStateData(requestID uint32, state types.SystemStateName) error
+	RequestSystemState(ctx context.Context, state types.SystemStateName) (*types.SystemStateData, error)
+	RequestAircraftLoaded(ctx context.Context) (string, error)
+	RequestFlightLoaded(ctx context.Context) (string, error)
+	RequestFlightPlan(ctx context.Context) (string, error)
+	RequestDialogMode(ctx context.Context) (bool, error)
+	RequestSimRunning(ctx context.Context) (bool, error)
+	// Flight files
+	LoadFlight(path string) error
+	SaveFlight(path string, title string, description string) error
+	LoadFlightPlan(path string) error
+	LoadFlightAndWait(ctx context.Context, path string) (*types.FilenameEventData, error)
+	SaveFlightAndWait(ctx context.Context, path string, title string, description string) (*types.FilenameEventData, error)
+	LoadFlightPlanAndWait(ctx context.Context, path string) (*types.FilenameEventData, error)
+	// SimObjects and liveries
+	EnumerateSimObjectsAndLiveries(requestID uint32, objectType types.SimObjectType) error
+	SimObjectsAndLiveries(ctx context.Context, objectType types.SimObjectType) ([]types.SimObjectLivery, error)
+	// Flow events (MSFS 2024)
+	SubscribeToFlowEvent() error
+	UnsubscribeToFlowEvent() error
+	// Jetways
+	RequestJetwayData(airportICAO string, parkingIndices []int32) error
+	Jetways(ctx context.Context, airportICAO string, parkingIndices []int32) ([]types.JetwayData, error)
+	// Actions (MSFS 2024)
+	ExecuteAction(ctx context.Context, actionID string, params []byte) (*types.ActionCallback, error)
 }
 
 func (e *Engine) Open() error {
@@ -35,7 +126,12 @@ func (e *Engine) Open() error {
 	e.system.mu.RUnlock()
 
 	if isConnected {
-		return fmt.Errorf("client, server connection is already open, skipping")
+		return ErrAlreadyOpen
+	}
+
+	// The DLL failed to load in New/NewWithCustomDLL
+	if e.loadErr != nil {
+		return e.loadErr
 	}
 
 	// Convert name to null-terminated byte array
@@ -58,12 +154,12 @@ func (e *Engine) Open() error {
 	response := uint32(hresult)
 
 	if !IsHRESULTSuccess(response) {
-		return fmt.Errorf("SimConnect_Open failed with HRESULT: 0x%08X", response)
+		return &HRESULTError{Function: "SimConnect_Open", Code: response}
 	}
 
 	// Verify handle was set or return an error
 	if e.handle == 0 {
-		return fmt.Errorf("SimConnect_Open succeeded but handle is null")
+		return ErrNullHandle
 	}
 
 	// Thread-safe update of connection status
@@ -114,7 +210,7 @@ func (e *Engine) Close() error {
 		response := uint32(hresult)
 
 		if !IsHRESULTSuccess(response) {
-			closeErr = fmt.Errorf("SimConnect_Close failed with HRESULT: 0x%08X", response)
+			closeErr = &HRESULTError{Function: "SimConnect_Close", Code: response}
 			return
 		}
 
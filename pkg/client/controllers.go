@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// EnumerateControllers requests the list of attached controllers
+// Pages arrive as "controllers_list" messages; the aggregated list follows as "controllers_list_complete"
+func (e *Engine) EnumerateControllers() error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	// Get handle and reset any previous pages (thread-safe)
+	e.mu.Lock()
+	clear(e.controllerPages)
+	handle := e.handle
+	e.mu.Unlock()
+
+	// Call SimConnect_EnumerateControllers
+	hresult, _ := e.call(SimConnect_EnumerateControllers, nil,
+		uintptr(handle), // hSimConnect
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: "SimConnect_EnumerateControllers", Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// Controllers enumerates the attached controllers and waits for all pages; Listen() must be active
+func (e *Engine) Controllers(ctx context.Context) ([]types.ControllerItem, error) {
+	if err := e.ensureListening(); err != nil {
+		return nil, err
+	}
+
+	// The call carries no request ID, so enumerations are serialized
+	e.controllersMu.Lock()
+	defer e.controllersMu.Unlock()
+
+	key := pendingKey{kind: "controllers_list"}
+	ch := e.expect(key)
+
+	if err := e.EnumerateControllers(); err != nil {
+		e.forget(key)
+		return nil, err
+	}
+
+	value, err := e.await(ctx, key, ch)
+	if err != nil {
+		return nil, err
+	}
+	return value.(*types.ControllerList).Controllers, nil
+}
+
+// collectControllerPage aggregates controller pages, returning the full list after the last page
+func (e *Engine) collectControllerPage(page *types.ControllerList) *types.ControllerList {
+	e.mu.Lock()
+	controllers, done := e.controllerPages.add(page.RequestID, page.Controllers, page.IsLastPage())
+	e.mu.Unlock()
+	if !done {
+		return nil
+	}
+
+	all := &types.ControllerList{
+		RequestID:   page.RequestID,
+		EntryNumber: page.EntryNumber,
+		OutOf:       page.OutOf,
+		Controllers: controllers,
+	}
+
+	e.resolve(pendingKey{kind: "controllers_list"}, all)
+	return all
+}
@@ -5,15 +5,21 @@ import (
 	"sync"
 	"syscall"
 
+	"github.com/mycrew-online/sdk/pkg/facilities"
 	"github.com/mycrew-online/sdk/pkg/types"
 )
 
 type Engine struct {
 	dll    *syscall.LazyDLL
 	handle uintptr
-	name   string
-	system *SystemState
-	stream chan any
+
+	// DLL loading results, fixed after construction
+	procedures   []*syscall.LazyProc // Every procedure resolved from the DLL, in load order
+	capabilities Capabilities        // Exports present in the DLL
+	loadErr      error               // Why the DLL could not be used, reported by Open
+	name         string
+	system       *SystemState
+	stream       chan any
 
 	// Shutdown coordination with async safety
 	ctx    context.Context
@@ -31,6 +37,71 @@ type Engine struct {
 	// Unhandled message tracking for monitoring and debugging
 	unhandledMessageStats map[types.SimConnectRecvID]int64 // MessageType → Count
 	lastUnhandledCheck    int64                            // Timestamp of last stats check
+
+	// Replies awaited by the context-aware request methods
+	pendingMu   sync.Mutex              // Protects pending
+	pending     map[pendingKey]chan any // Reply key → waiting channel
+	internalIDs uint32                  // Counter for SDK-allocated IDs (atomic)
+
+	// Facility definitions and in-flight facility requests
+	facilityDefinitions map[uint32]*facilities.Definition // DefineID → field layout
+	facilityRequests    map[uint32]*facilities.Assembler  // RequestID → record tree being assembled
+
+	// Facility list requests and subscriptions, with pages collected until the list is complete
+	facilityListTypes map[uint32]types.FacilityListType  // RequestID → list type
+	facilityListPages map[uint32]*types.FacilityListData // RequestID → pages received so far
+
+	// Input event enumerations, with descriptors kept to resolve names and hashes
+	inputEventPages  listPages[types.InputEventDescriptor] // RequestID → descriptors received so far
+	inputEventNames  map[uint64]string                     // Hash → input event name
+	inputEventHashes map[string]uint64                     // Input event name → hash
+
+	// System event subscriptions, used to decode payloads and track their state
+	systemEvents map[uint32]*types.SystemEventSubscription // EventID → subscription
+
+	// Named-event registry, used to label received events
+	clientEvents map[uint32]*types.EventRegistration // EventID → mapped client or input event
+	groupNames   map[uint32]string                   // GroupID → name given with NameNotificationGroup
+
+	// Client data definitions, used to decode and encode client data areas
+	clientDataDefinitions map[uint32]*types.ClientDataDefinition // DefineID → entry layout
+
+	// Controller enumeration, which carries no request ID
+	controllersMu   sync.Mutex                      // Serializes Controllers calls
+	controllerPages listPages[types.ControllerItem] // Controllers received so far
+
+	// SimObject and livery enumerations
+	liveryPages listPages[types.SimObjectLivery] // RequestID → entries received so far
+
+	// Jetway data requests, which carry no request ID
+	jetwayMu    sync.Mutex                  // Serializes Jetways calls
+	jetwayPages listPages[types.JetwayData] // Jetways received so far
+
+	// Keyboard and joystick inputs mapped with BindInput
+	inputBindings map[uint32]*types.InputBinding // Down EventID → binding
+
+	// Event interceptors registered with InterceptEvent
+	interceptors    map[uint32]*EventInterceptor    // EventID → interceptor
+	spareIntercepts map[types.KEvent][]interceptIDs // Event name → IDs already mapped to it, free for reuse
+
+	// Key events mapped by Send
+	keyEvents map[types.KEvent]types.ClientEventID // Normalized event name → internal event ID
+
+	// Sent packets, kept to trace exceptions back to the call that caused them
+	sendMu  sync.Mutex  // Serializes outgoing calls with their packet ID lookup
+	packets *packetRing // Recently sent packets
+
+	// Actions started with ExecuteAction, keyed by the packet that sent them so exceptions can fail them
+	actionMu      sync.Mutex               // Orders packet registration before exception handling
+	actionPackets map[uint32]pendingAction // SendID → waiting call
+
+	// System events subscribed by the SDK to confirm flight and flight plan loads
+	flightEvents map[string]uint32 // System event name → internal event ID
+
+	// Flight file calls waiting for their confirming event, which carries only the filename
+	flightMu       sync.Mutex     // Serializes LoadFlightAndWait, SaveFlightAndWait and LoadFlightPlanAndWait
+	flightPacketMu sync.Mutex     // Orders packet registration before exception handling
+	flightPending  *pendingFlight // Call in flight, nil when none
 }
 
 type SystemState struct {
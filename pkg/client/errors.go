@@ -0,0 +1,71 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// Errors returned across the Engine, matched with errors.Is
+var (
+	// ErrNotConnected is returned by calls made before Open or after Close
+	ErrNotConnected = errors.New("not connected to simulator")
+	// ErrAlreadyOpen is returned by Open on an open connection
+	ErrAlreadyOpen = errors.New("connection is already open")
+	// ErrUnknownDefinition is returned for a definition ID that was not registered with the SDK
+	ErrUnknownDefinition = errors.New("unknown definition")
+	// ErrDLLNotLoaded is returned when the SimConnect DLL cannot be loaded or lacks the core exports
+	ErrDLLNotLoaded = errors.New("SimConnect DLL could not be loaded")
+	// ErrNullHandle is returned by Open when SimConnect_Open succeeds without setting a handle
+	ErrNullHandle = errors.New("SimConnect_Open succeeded but handle is null")
+)
+
+// HRESULTError is returned when a SimConnect call fails synchronously
+type HRESULTError struct {
+	Function string // SimConnect function, e.g. "SimConnect_AddToDataDefinition"
+	Code     uint32 // HRESULT, e.g. E_FAIL
+}
+
+func (err *HRESULTError) Error() string {
+	return fmt.Sprintf("%s failed: 0x%08X (%s: %s)", err.Function, err.Code, HRESULTName(err.Code), HRESULTDescription(err.Code))
+}
+
+// Is matches an *HRESULTError with the same Code, and the same Function when the target sets one,
+// so errors.Is(err, &HRESULTError{Code: E_FAIL}) matches any E_FAIL
+func (err *HRESULTError) Is(target error) bool {
+	t, ok := target.(*HRESULTError)
+	if !ok {
+		return false
+	}
+	return t.Code == err.Code && (t.Function == "" || t.Function == err.Function)
+}
+
+// ExceptionError is returned when the simulator rejects a packet with an exception,
+// e.g. by the *AndVerify methods. It unwraps to the types.SimConnectException code,
+// so errors.Is(err, types.SIMCONNECT_EXCEPTION_NAME_UNRECOGNIZED) matches it.
+type ExceptionError struct {
+	Exception *types.ExceptionData
+}
+
+func (err *ExceptionError) Error() string {
+	exception := err.Exception
+	message := fmt.Sprintf("%s: %s", exception.ExceptionName, exception.Description)
+	if exception.Packet != nil {
+		message = fmt.Sprintf("%s raised %s", exception.Packet.Function, message)
+	}
+	if exception.Field != "" {
+		message = fmt.Sprintf("%s (field %q)", message, exception.Field)
+	}
+	return message
+}
+
+// Unwrap returns the exception code
+func (err *ExceptionError) Unwrap() error {
+	return err.Exception.ExceptionCode
+}
+
+// Severity returns the severity of the exception
+func (err *ExceptionError) Severity() types.ExceptionSeverity {
+	return err.Exception.Severity
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"fmt"
+	"sort"
 	"syscall"
 	"unsafe"
 
@@ -19,7 +20,7 @@ func (e *Engine) SubscribeToSystemEvent(eventID uint32, eventName string) error
 	e.system.mu.RUnlock()
 
 	if !isConnected {
-		return fmt.Errorf("not connected to SimConnect")
+		return ErrNotConnected
 	}
 
 	// Convert event name to C string
@@ -29,19 +30,158 @@ func (e *Engine) SubscribeToSystemEvent(eventID uint32, eventName string) error
 	}
 
 	// Call SimConnect_SubscribeToSystemEvent
-	r1, _, err := SimConnect_SubscribeToSystemEvent.Call(
+	r1, _ := e.call(SimConnect_SubscribeToSystemEvent, []any{eventID, eventName},
 		uintptr(e.handle),
 		uintptr(eventID),
 		uintptr(unsafe.Pointer(eventNamePtr)),
 	)
 
 	if r1 != 0 {
-		return fmt.Errorf("SimConnect_SubscribeToSystemEvent failed: %w", err)
+		return &HRESULTError{Function: "SimConnect_SubscribeToSystemEvent", Code: uint32(r1)}
 	}
 
+	// Remember the name so the event payload can be decoded
+	e.systemEvents[eventID] = &types.SystemEventSubscription{
+		EventID: eventID,
+		Name:    types.SystemEventName(eventName),
+		Status:  types.SystemEventActive,
+	}
+
+	return nil
+}
+
+// SubscribeSystemEvent subscribes to a catalogued system event using an SDK-allocated event ID
+// Decoded values arrive as "system_event" (*types.SystemEvent) alongside the raw message
+func (e *Engine) SubscribeSystemEvent(name types.SystemEventName) (uint32, error) {
+	if _, known := types.LookupSystemEvent(name); !known {
+		return 0, fmt.Errorf("unknown system event %q", name)
+	}
+
+	eventID := e.nextInternalID()
+	if err := e.SubscribeToSystemEvent(eventID, string(name)); err != nil {
+		return 0, err
+	}
+	return eventID, nil
+}
+
+// SetSystemEventState turns delivery of a subscribed system event on or off without unsubscribing
+func (e *Engine) SetSystemEventState(eventID uint32, state types.SimConnectState) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// Check if connected
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	sub, exists := e.systemEvents[eventID]
+	if !exists || sub.Status == types.SystemEventUnsubscribed {
+		return fmt.Errorf("system event %d is not subscribed", eventID)
+	}
+
+	// Call SimConnect_SetSystemEventState
+	hresult, _ := e.call(SimConnect_SetSystemEventState, []any{eventID, state},
+		uintptr(e.handle), // hSimConnect
+		uintptr(eventID),  // EventID
+		uintptr(state),    // dwState
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: "SimConnect_SetSystemEventState", Code: uint32(hresult)}
+	}
+
+	if state == types.SIMCONNECT_STATE_ON {
+		sub.Status = types.SystemEventActive
+	} else {
+		sub.Status = types.SystemEventInactive
+	}
 	return nil
 }
 
+// UnsubscribeFromSystemEvent stops notifications for a subscribed system event
+func (e *Engine) UnsubscribeFromSystemEvent(eventID uint32) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// Check if connected
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	// Call SimConnect_UnsubscribeFromSystemEvent
+	hresult, _ := e.call(SimConnect_UnsubscribeFromSystemEvent, []any{eventID},
+		uintptr(e.handle), // hSimConnect
+		uintptr(eventID),  // EventID
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: "SimConnect_UnsubscribeFromSystemEvent", Code: uint32(hresult)}
+	}
+
+	// Keep the entry so events still in the queue are decoded and the state can be queried
+	if sub, exists := e.systemEvents[eventID]; exists {
+		sub.Status = types.SystemEventUnsubscribed
+	}
+	return nil
+}
+
+// SystemEventState returns the bookkeeping state of a system event subscription
+func (e *Engine) SystemEventState(eventID uint32) (types.SystemEventStatus, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	sub, exists := e.systemEvents[eventID]
+	if !exists {
+		return types.SystemEventUnsubscribed, false
+	}
+	return sub.Status, true
+}
+
+// SystemEventSubscriptions returns a snapshot of all known system event subscriptions
+func (e *Engine) SystemEventSubscriptions() []types.SystemEventSubscription {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	subs := make([]types.SystemEventSubscription, 0, len(e.systemEvents))
+	for _, sub := range e.systemEvents {
+		subs = append(subs, *sub)
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].EventID < subs[j].EventID })
+	return subs
+}
+
+// systemEventName returns the system event subscribed with eventID, if any
+func (e *Engine) systemEventName(eventID uint32) types.SystemEventName {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if sub, exists := e.systemEvents[eventID]; exists {
+		return sub.Name
+	}
+	return ""
+}
+
+// decodeSystemEvent wraps a parsed event payload into a *types.SystemEvent when eventID was subscribed by name
+func (e *Engine) decodeSystemEvent(eventID uint32, data uint32, value any) *types.SystemEvent {
+	name := e.systemEventName(eventID)
+	if name == "" {
+		return nil
+	}
+
+	event := types.DecodeSystemEvent(name, eventID, data)
+	if value != nil {
+		event.Value = value
+	}
+	return event
+}
+
 // MapClientEventToSimEvent maps a client event ID to a simulator event name
 func (e *Engine) MapClientEventToSimEvent(eventID types.ClientEventID, eventName string) error {
 	e.mu.Lock()
@@ -53,7 +193,7 @@ func (e *Engine) MapClientEventToSimEvent(eventID types.ClientEventID, eventName
 	e.system.mu.RUnlock()
 
 	if !isConnected {
-		return fmt.Errorf("not connected to SimConnect")
+		return ErrNotConnected
 	}
 
 	// Convert event name to C string
@@ -63,14 +203,21 @@ func (e *Engine) MapClientEventToSimEvent(eventID types.ClientEventID, eventName
 	}
 
 	// Call SimConnect_MapClientEventToSimEvent
-	r1, _, err := SimConnect_MapClientEventToSimEvent.Call(
+	r1, _ := e.call(SimConnect_MapClientEventToSimEvent, []any{eventID, eventName},
 		uintptr(e.handle),
 		uintptr(eventID),
 		uintptr(unsafe.Pointer(eventNamePtr)),
 	)
 
 	if r1 != 0 {
-		return fmt.Errorf("SimConnect_MapClientEventToSimEvent failed: %w", err)
+		return &HRESULTError{Function: "SimConnect_MapClientEventToSimEvent", Code: uint32(r1)}
+	}
+
+	// Remember the name so received events can be labelled
+	e.clientEvents[uint32(eventID)] = &types.EventRegistration{
+		EventID: uint32(eventID),
+		Name:    eventName,
+		Kind:    types.EventKindClient,
 	}
 
 	return nil
@@ -87,7 +234,7 @@ func (e *Engine) AddClientEventToNotificationGroup(groupID types.NotificationGro
 	e.system.mu.RUnlock()
 
 	if !isConnected {
-		return fmt.Errorf("not connected to SimConnect")
+		return ErrNotConnected
 	}
 
 	// Convert bool to int32 (0 = false, 1 = true)
@@ -97,7 +244,7 @@ func (e *Engine) AddClientEventToNotificationGroup(groupID types.NotificationGro
 	}
 
 	// Call SimConnect_AddClientEventToNotificationGroup
-	r1, _, err := SimConnect_AddClientEventToNotificationGroup.Call(
+	r1, _ := e.call(SimConnect_AddClientEventToNotificationGroup, []any{groupID, eventID, maskable},
 		uintptr(e.handle),
 		uintptr(groupID),
 		uintptr(eventID),
@@ -105,7 +252,7 @@ func (e *Engine) AddClientEventToNotificationGroup(groupID types.NotificationGro
 	)
 
 	if r1 != 0 {
-		return fmt.Errorf("SimConnect_AddClientEventToNotificationGroup failed: %w", err)
+		return &HRESULTError{Function: "SimConnect_AddClientEventToNotificationGroup", Code: uint32(r1)}
 	}
 
 	return nil
@@ -122,18 +269,18 @@ func (e *Engine) SetNotificationGroupPriority(groupID types.NotificationGroupID,
 	e.system.mu.RUnlock()
 
 	if !isConnected {
-		return fmt.Errorf("not connected to SimConnect")
+		return ErrNotConnected
 	}
 
 	// Call SimConnect_SetNotificationGroupPriority
-	r1, _, err := SimConnect_SetNotificationGroupPriority.Call(
+	r1, _ := e.call(SimConnect_SetNotificationGroupPriority, []any{groupID, priority},
 		uintptr(e.handle),
 		uintptr(groupID),
 		uintptr(priority),
 	)
 
 	if r1 != 0 {
-		return fmt.Errorf("SimConnect_SetNotificationGroupPriority failed: %w", err)
+		return &HRESULTError{Function: "SimConnect_SetNotificationGroupPriority", Code: uint32(r1)}
 	}
 
 	return nil
@@ -150,11 +297,11 @@ func (e *Engine) TransmitClientEvent(objectID uint32, eventID types.ClientEventI
 	e.system.mu.RUnlock()
 
 	if !isConnected {
-		return fmt.Errorf("not connected to SimConnect")
+		return ErrNotConnected
 	}
 
 	// Call SimConnect_TransmitClientEvent
-	r1, _, err := SimConnect_TransmitClientEvent.Call(
+	r1, _ := e.call(SimConnect_TransmitClientEvent, []any{objectID, eventID, data, groupID, flags},
 		uintptr(e.handle),
 		uintptr(objectID),
 		uintptr(eventID),
@@ -164,8 +311,58 @@ func (e *Engine) TransmitClientEvent(objectID uint32, eventID types.ClientEventI
 	)
 
 	if r1 != 0 {
-		return fmt.Errorf("SimConnect_TransmitClientEvent failed: %w", err)
+		return &HRESULTError{Function: "SimConnect_TransmitClientEvent", Code: uint32(r1)}
 	}
 
 	return nil
 }
+
+// NameNotificationGroup gives a notification group a name that is reported on its received events
+func (e *Engine) NameNotificationGroup(groupID types.NotificationGroupID, name string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.groupNames[uint32(groupID)] = name
+}
+
+// EventName returns the registry entry of a mapped client, input or subscribed system event
+func (e *Engine) EventName(eventID uint32) (types.EventRegistration, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if reg, exists := e.clientEvents[eventID]; exists {
+		return *reg, true
+	}
+	if sub, exists := e.systemEvents[eventID]; exists {
+		return types.EventRegistration{EventID: eventID, Name: string(sub.Name), Kind: types.EventKindSystem}, true
+	}
+	return types.EventRegistration{EventID: eventID, Kind: types.EventKindUnknown}, false
+}
+
+// EventRegistrations returns a snapshot of all named client, input and system events
+func (e *Engine) EventRegistrations() []types.EventRegistration {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	regs := make([]types.EventRegistration, 0, len(e.clientEvents)+len(e.systemEvents))
+	for _, reg := range e.clientEvents {
+		regs = append(regs, *reg)
+	}
+	for eventID, sub := range e.systemEvents {
+		if _, mapped := e.clientEvents[eventID]; !mapped {
+			regs = append(regs, types.EventRegistration{EventID: eventID, Name: string(sub.Name), Kind: types.EventKindSystem})
+		}
+	}
+	sort.Slice(regs, func(i, j int) bool { return regs[i].EventID < regs[j].EventID })
+	return regs
+}
+
+// classifyEvent resolves the kind, event name and group name of a received event
+func (e *Engine) classifyEvent(eventID uint32, groupID uint32) (types.EventKind, string, string) {
+	reg, _ := e.EventName(eventID)
+
+	e.mu.RLock()
+	groupName := e.groupNames[groupID]
+	e.mu.RUnlock()
+
+	return reg.Kind, reg.Name, groupName
+}
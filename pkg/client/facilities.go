@@ -0,0 +1,405 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"github.com/mycrew-online/sdk/pkg/facilities"
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// AddToFacilityDefinition adds a single line ("OPEN AIRPORT", "ICAO", "CLOSE AIRPORT", ...) to a facility definition
+// Records requested through definitions built this way are delivered undecoded; prefer RegisterFacilityDefinition
+func (e *Engine) AddToFacilityDefinition(defID uint32, fieldName string) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	fieldNamePtr, err := syscall.BytePtrFromString(fieldName)
+	if err != nil {
+		return fmt.Errorf("invalid facility field name: %v", err)
+	}
+
+	// Thread-safe access to handle
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	// Call SimConnect_AddToFacilityDefinition
+	hresult, _ := e.callField(SimConnect_AddToFacilityDefinition, fieldName, []any{defID, fieldName},
+		uintptr(handle),                       // hSimConnect
+		uintptr(defID),                        // DefineID
+		uintptr(unsafe.Pointer(fieldNamePtr)), // FieldName
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return fmt.Errorf("field %q: %w", fieldName, &HRESULTError{Function: "SimConnect_AddToFacilityDefinition", Code: uint32(hresult)})
+	}
+	return nil
+}
+
+// RegisterFacilityDefinition sends every line of a facility definition and remembers its layout,
+// so FACILITY_DATA records for this DefineID can be decoded into facilities.Node trees
+func (e *Engine) RegisterFacilityDefinition(defID uint32, def *facilities.Definition) error {
+	lines, err := def.Lines()
+	if err != nil {
+		return fmt.Errorf("invalid facility definition: %w", err)
+	}
+
+	for _, line := range lines {
+		if err := e.AddToFacilityDefinition(defID, line); err != nil {
+			return err
+		}
+	}
+
+	// Store the layout for decoding (thread-safe)
+	e.mu.Lock()
+	e.facilityDefinitions[defID] = def
+	e.mu.Unlock()
+
+	return nil
+}
+
+// RequestFacilityData requests the facility identified by ICAO and region using a registered definition
+// Records arrive as "facility_data" messages; the decoded tree follows as "facility" once FACILITY_DATA_END is received
+func (e *Engine) RequestFacilityData(defID uint32, requestID uint32, icao string, region string) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	icaoPtr, err := syscall.BytePtrFromString(icao)
+	if err != nil {
+		return fmt.Errorf("invalid ICAO: %v", err)
+	}
+
+	// Region may be empty; SimConnect then matches the first facility with this ICAO
+	regionPtr, err := syscall.BytePtrFromString(region)
+	if err != nil {
+		return fmt.Errorf("invalid region: %v", err)
+	}
+
+	// Prepare the record tree before the request goes out (thread-safe)
+	e.mu.Lock()
+	if def, exists := e.facilityDefinitions[defID]; exists {
+		e.facilityRequests[requestID] = facilities.NewAssembler(def)
+	}
+	handle := e.handle
+	e.mu.Unlock()
+
+	// Call SimConnect_RequestFacilityData
+	hresult, _ := e.call(SimConnect_RequestFacilityData, []any{defID, requestID, icao, region},
+		uintptr(handle),                    // hSimConnect
+		uintptr(defID),                     // DefineID
+		uintptr(requestID),                 // RequestID
+		uintptr(unsafe.Pointer(icaoPtr)),   // ICAO
+		uintptr(unsafe.Pointer(regionPtr)), // Region
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		e.mu.Lock()
+		delete(e.facilityRequests, requestID)
+		e.mu.Unlock()
+		return &HRESULTError{Function: "SimConnect_RequestFacilityData", Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// RequestFacility requests a facility and waits until its decoded record tree is complete
+// The definition must have been registered with RegisterFacilityDefinition and Listen() must be active
+func (e *Engine) RequestFacility(ctx context.Context, defID uint32, icao string, region string) (*facilities.Node, error) {
+	if err := e.ensureListening(); err != nil {
+		return nil, err
+	}
+
+	e.mu.RLock()
+	_, registered := e.facilityDefinitions[defID]
+	e.mu.RUnlock()
+
+	if !registered {
+		return nil, fmt.Errorf("%w: facility defID %d not registered - call RegisterFacilityDefinition first", ErrUnknownDefinition, defID)
+	}
+
+	requestID := e.nextInternalID()
+	key := pendingKey{kind: "facility", id: requestID}
+	ch := e.expect(key)
+
+	if err := e.RequestFacilityData(defID, requestID, icao, region); err != nil {
+		e.forget(key)
+		return nil, err
+	}
+
+	value, err := e.await(ctx, key, ch)
+	if err != nil {
+		e.mu.Lock()
+		delete(e.facilityRequests, requestID)
+		e.mu.Unlock()
+		return nil, err
+	}
+
+	result := value.(*facilities.Result)
+	if result.Root == nil {
+		return nil, fmt.Errorf("facility %s %s not found", icao, region)
+	}
+	return result.Root, nil
+}
+
+// collectFacilityRecord adds a FACILITY_DATA record to the tree of its request
+func (e *Engine) collectFacilityRecord(record *types.FacilityData) (*facilities.Node, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	assembler, exists := e.facilityRequests[record.RequestID]
+	if !exists {
+		return nil, nil // Definition was not registered through RegisterFacilityDefinition
+	}
+	return assembler.Add(record)
+}
+
+// completeFacilityRequest finalises the tree of a request when FACILITY_DATA_END arrives
+func (e *Engine) completeFacilityRequest(requestID uint32) *facilities.Result {
+	e.mu.Lock()
+	assembler, exists := e.facilityRequests[requestID]
+	delete(e.facilityRequests, requestID)
+	e.mu.Unlock()
+
+	if !exists {
+		return nil
+	}
+
+	result := &facilities.Result{
+		RequestID: requestID,
+		Root:      assembler.Root(),
+	}
+	e.resolve(pendingKey{kind: "facility", id: requestID}, result)
+	return result
+}
+
+// RequestFacilitiesList requests the facilities of the given type currently in the reality bubble cache
+// Pages arrive as "facility_list" messages; the aggregated list is attached as "facility_list_complete" on the last page
+func (e *Engine) RequestFacilitiesList(listType types.FacilityListType, requestID uint32) error {
+	return e.callFacilityList(SimConnect_RequestFacilitiesList, "SimConnect_RequestFacilitiesList", listType, requestID)
+}
+
+// RequestFacilitiesList_EX1 requests a minimal list (ICAO and position) of the facilities of the given type
+// Replies arrive as FACILITY_MINIMAL_LIST pages
+func (e *Engine) RequestFacilitiesList_EX1(listType types.FacilityListType, requestID uint32) error {
+	return e.callFacilityList(SimConnect_RequestFacilitiesList_EX1, "SimConnect_RequestFacilitiesList_EX1", listType, requestID)
+}
+
+// SubscribeToFacilities subscribes to facilities of the given type entering the reality bubble
+// The initial list and every later change are delivered under requestID
+func (e *Engine) SubscribeToFacilities(listType types.FacilityListType, requestID uint32) error {
+	return e.callFacilityList(SimConnect_SubscribeToFacilities, "SimConnect_SubscribeToFacilities", listType, requestID)
+}
+
+// SubscribeToFacilities_EX1 subscribes to facilities entering and leaving the reality bubble
+// Facilities coming into range are reported under newInRangeRequestID, those going out of range under oldOutRangeRequestID
+func (e *Engine) SubscribeToFacilities_EX1(listType types.FacilityListType, newInRangeRequestID uint32, oldOutRangeRequestID uint32) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	// Remember the list type of both request IDs for decoding before replies can arrive (thread-safe)
+	e.mu.Lock()
+	e.facilityListTypes[newInRangeRequestID] = listType
+	e.facilityListTypes[oldOutRangeRequestID] = listType
+	handle := e.handle
+	e.mu.Unlock()
+
+	// Call SimConnect_SubscribeToFacilities_EX1
+	hresult, _ := e.call(SimConnect_SubscribeToFacilities_EX1, []any{listType, newInRangeRequestID, oldOutRangeRequestID},
+		uintptr(handle),               // hSimConnect
+		uintptr(listType),             // type
+		uintptr(newInRangeRequestID),  // newElemInRangeRequestID
+		uintptr(oldOutRangeRequestID), // oldElemOutRangeRequestID
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		// No replies will come for a rejected call
+		e.mu.Lock()
+		delete(e.facilityListTypes, newInRangeRequestID)
+		delete(e.facilityListTypes, oldOutRangeRequestID)
+		e.mu.Unlock()
+		return &HRESULTError{Function: "SimConnect_SubscribeToFacilities_EX1", Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// UnsubscribeToFacilities stops the facility subscription of the given type
+func (e *Engine) UnsubscribeToFacilities(listType types.FacilityListType) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	// Thread-safe access to handle
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	// Call SimConnect_UnsubscribeToFacilities
+	hresult, _ := e.call(SimConnect_UnsubscribeToFacilities, []any{listType},
+		uintptr(handle),   // hSimConnect
+		uintptr(listType), // type
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: "SimConnect_UnsubscribeToFacilities", Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// UnsubscribeToFacilities_EX1 stops the in-range and/or out-of-range parts of an EX1 facility subscription
+func (e *Engine) UnsubscribeToFacilities_EX1(listType types.FacilityListType, unsubscribeNewInRange bool, unsubscribeOldOutRange bool) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	// Convert bools to int32 (0 = false, 1 = true)
+	newInRange := 0
+	if unsubscribeNewInRange {
+		newInRange = 1
+	}
+	oldOutRange := 0
+	if unsubscribeOldOutRange {
+		oldOutRange = 1
+	}
+
+	// Thread-safe access to handle
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	// Call SimConnect_UnsubscribeToFacilities_EX1
+	hresult, _ := e.call(SimConnect_UnsubscribeToFacilities_EX1, []any{listType, unsubscribeNewInRange, unsubscribeOldOutRange},
+		uintptr(handle),      // hSimConnect
+		uintptr(listType),    // type
+		uintptr(newInRange),  // bUnsubscribeNewInRange
+		uintptr(oldOutRange), // bUnsubscribeOldOutRange
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: "SimConnect_UnsubscribeToFacilities_EX1", Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// RequestFacilities requests a facility list and waits until all pages have arrived
+// Set minimal to use RequestFacilitiesList_EX1; Listen() must be active
+func (e *Engine) RequestFacilities(ctx context.Context, listType types.FacilityListType, minimal bool) (*types.FacilityListData, error) {
+	if err := e.ensureListening(); err != nil {
+		return nil, err
+	}
+
+	requestID := e.nextInternalID()
+	key := pendingKey{kind: "facility_list", id: requestID}
+	ch := e.expect(key)
+
+	var err error
+	if minimal {
+		err = e.RequestFacilitiesList_EX1(listType, requestID)
+	} else {
+		err = e.RequestFacilitiesList(listType, requestID)
+	}
+	if err != nil {
+		e.forget(key)
+		return nil, err
+	}
+
+	value, err := e.await(ctx, key, ch)
+
+	// One-shot request, its bookkeeping is no longer needed
+	e.mu.Lock()
+	delete(e.facilityListTypes, requestID)
+	delete(e.facilityListPages, requestID)
+	e.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	return value.(*types.FacilityListData), nil
+}
+
+// callFacilityList issues one of the (type, requestID) facility list calls
+func (e *Engine) callFacilityList(proc *syscall.LazyProc, name string, listType types.FacilityListType, requestID uint32) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	// Remember the list type for decoding minimal lists before replies can arrive (thread-safe)
+	e.mu.Lock()
+	e.facilityListTypes[requestID] = listType
+	handle := e.handle
+	e.mu.Unlock()
+
+	hresult, _ := e.call(proc, []any{listType, requestID},
+		uintptr(handle),    // hSimConnect
+		uintptr(listType),  // type
+		uintptr(requestID), // RequestID
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		// No replies will come for a rejected call
+		e.mu.Lock()
+		delete(e.facilityListTypes, requestID)
+		e.mu.Unlock()
+		return &HRESULTError{Function: name, Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// collectFacilityListPage adds a list page to its request and returns the aggregated list once complete
+func (e *Engine) collectFacilityListPage(page *types.FacilityListData) *types.FacilityListData {
+	e.mu.Lock()
+	aggregated, exists := e.facilityListPages[page.RequestID]
+	if !exists {
+		aggregated = &types.FacilityListData{
+			RequestID: page.RequestID,
+			ListType:  page.ListType,
+		}
+		e.facilityListPages[page.RequestID] = aggregated
+	}
+	aggregated.Append(page)
+
+	if !page.IsLastPage() {
+		e.mu.Unlock()
+		return nil
+	}
+	// Subscriptions reuse the request ID for every batch, so start over next time
+	delete(e.facilityListPages, page.RequestID)
+	e.mu.Unlock()
+
+	e.resolve(pendingKey{kind: "facility_list", id: page.RequestID}, aggregated)
+	return aggregated
+}
@@ -0,0 +1,269 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// System events that report the outcome of flight file operations
+const (
+	flightLoadedEvent        = "FlightLoaded"
+	flightSavedEvent         = "FlightSaved"
+	flightPlanActivatedEvent = "FlightPlanActivated"
+)
+
+// pendingFlight is a flight file call waiting for its confirming event
+type pendingFlight struct {
+	eventID uint32 // Internal ID of the confirming system event
+	path    string // File passed to the call
+	sendID  uint32 // Packet of the call, 0 when untracked
+}
+
+// LoadFlight loads a saved flight (.FLT) file
+// The simulator reports completion with the FlightLoaded system event
+func (e *Engine) LoadFlight(path string) error {
+	_, err := e.callFlightFile(SimConnect_FlightLoad, "SimConnect_FlightLoad", path)
+	return err
+}
+
+// LoadFlightPlan loads and activates a flight plan (.PLN) file
+// The simulator reports completion with the FlightPlanActivated system event,
+// or a LOAD_FLIGHTPLAN_FAILED exception when the plan cannot be loaded
+func (e *Engine) LoadFlightPlan(path string) error {
+	_, err := e.callFlightFile(SimConnect_FlightPlanLoad, "SimConnect_FlightPlanLoad", path)
+	return err
+}
+
+// SaveFlight saves the current flight to a .FLT file with the given title and description
+// The simulator reports completion with the FlightSaved system event
+func (e *Engine) SaveFlight(path string, title string, description string) error {
+	_, err := e.saveFlight(path, title, description)
+	return err
+}
+
+// saveFlight issues SimConnect_FlightSave and returns the packet ID of the call
+func (e *Engine) saveFlight(path string, title string, description string) (uint32, error) {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return 0, ErrNotConnected
+	}
+
+	pathPtr, err := syscall.BytePtrFromString(path)
+	if err != nil {
+		return 0, fmt.Errorf("invalid path: %v", err)
+	}
+	titlePtr, err := syscall.BytePtrFromString(title)
+	if err != nil {
+		return 0, fmt.Errorf("invalid title: %v", err)
+	}
+	descriptionPtr, err := syscall.BytePtrFromString(description)
+	if err != nil {
+		return 0, fmt.Errorf("invalid description: %v", err)
+	}
+
+	// Get handle (thread-safe)
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	// Call SimConnect_FlightSave
+	hresult, sendID := e.call(SimConnect_FlightSave, []any{path, title, description},
+		uintptr(handle),                         // hSimConnect
+		uintptr(unsafe.Pointer(pathPtr)),        // szFileName
+		uintptr(unsafe.Pointer(titlePtr)),       // szTitle
+		uintptr(unsafe.Pointer(descriptionPtr)), // szDescription
+		0,                                       // Flags (unused)
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return 0, &HRESULTError{Function: "SimConnect_FlightSave", Code: uint32(hresult)}
+	}
+	return sendID, nil
+}
+
+// LoadFlightAndWait loads a flight and waits for the FlightLoaded event; Listen() must be active
+func (e *Engine) LoadFlightAndWait(ctx context.Context, path string) (*types.FilenameEventData, error) {
+	return e.flightFileAndWait(ctx, flightLoadedEvent, path, func() (uint32, error) {
+		return e.callFlightFile(SimConnect_FlightLoad, "SimConnect_FlightLoad", path)
+	})
+}
+
+// SaveFlightAndWait saves the flight and waits for the FlightSaved event; Listen() must be active
+func (e *Engine) SaveFlightAndWait(ctx context.Context, path string, title string, description string) (*types.FilenameEventData, error) {
+	return e.flightFileAndWait(ctx, flightSavedEvent, path, func() (uint32, error) {
+		return e.saveFlight(path, title, description)
+	})
+}
+
+// LoadFlightPlanAndWait loads a flight plan and waits for the FlightPlanActivated event; Listen() must be active
+func (e *Engine) LoadFlightPlanAndWait(ctx context.Context, path string) (*types.FilenameEventData, error) {
+	return e.flightFileAndWait(ctx, flightPlanActivatedEvent, path, func() (uint32, error) {
+		return e.callFlightFile(SimConnect_FlightPlanLoad, "SimConnect_FlightPlanLoad", path)
+	})
+}
+
+// callFlightFile issues one of the single-filename flight calls and returns the packet ID of the call
+func (e *Engine) callFlightFile(proc *syscall.LazyProc, name string, path string) (uint32, error) {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return 0, ErrNotConnected
+	}
+
+	pathPtr, err := syscall.BytePtrFromString(path)
+	if err != nil {
+		return 0, fmt.Errorf("invalid path: %v", err)
+	}
+
+	// Get handle (thread-safe)
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	hresult, sendID := e.call(proc, []any{path},
+		uintptr(handle),                  // hSimConnect
+		uintptr(unsafe.Pointer(pathPtr)), // szFileName
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return 0, &HRESULTError{Function: name, Code: uint32(hresult)}
+	}
+	return sendID, nil
+}
+
+// flightFileAndWait subscribes to the confirming system event if needed, runs call and waits for
+// the event naming path. Calls are serialized: the events carry no request ID, only the filename.
+func (e *Engine) flightFileAndWait(ctx context.Context, eventName string, path string, call func() (uint32, error)) (*types.FilenameEventData, error) {
+	if err := e.ensureListening(); err != nil {
+		return nil, err
+	}
+
+	eventID, err := e.flightEventID(eventName)
+	if err != nil {
+		return nil, err
+	}
+
+	e.flightMu.Lock()
+	defer e.flightMu.Unlock()
+
+	key := pendingKey{kind: "flight_event", id: eventID}
+	ch := e.expect(key)
+
+	e.mu.Lock()
+	e.flightPending = &pendingFlight{eventID: eventID, path: path}
+	e.mu.Unlock()
+	defer func() {
+		e.mu.Lock()
+		e.flightPending = nil
+		e.mu.Unlock()
+	}()
+
+	// Register the packet before an exception for it can be dispatched (see failFlightPlanLoad)
+	e.flightPacketMu.Lock()
+	sendID, err := call()
+	e.mu.Lock()
+	e.flightPending.sendID = sendID
+	e.mu.Unlock()
+	e.flightPacketMu.Unlock()
+
+	if err != nil {
+		e.forget(key)
+		return nil, err
+	}
+
+	value, err := e.await(ctx, key, ch)
+	if err != nil {
+		return nil, err
+	}
+	return value.(*types.FilenameEventData), nil
+}
+
+// flightEventID returns the internal event ID subscribed to eventName, subscribing on first use
+func (e *Engine) flightEventID(eventName string) (uint32, error) {
+	e.mu.RLock()
+	eventID, exists := e.flightEvents[eventName]
+	e.mu.RUnlock()
+
+	if exists {
+		return eventID, nil
+	}
+
+	eventID = e.nextInternalID()
+	if err := e.SubscribeToSystemEvent(eventID, eventName); err != nil {
+		return 0, err
+	}
+
+	e.mu.Lock()
+	if existing, raced := e.flightEvents[eventName]; raced {
+		eventID = existing // Another caller subscribed first; both IDs deliver the same event
+	} else {
+		e.flightEvents[eventName] = eventID
+	}
+	e.mu.Unlock()
+
+	return eventID, nil
+}
+
+// resolveFlightEvent hands a filename event to the waiting flight file call it confirms
+func (e *Engine) resolveFlightEvent(event *types.FilenameEventData) {
+	e.mu.RLock()
+	pending := e.flightPending
+	e.mu.RUnlock()
+
+	// Events for files loaded or saved from the simulator UI are not ours
+	if pending == nil || pending.eventID != event.EventID || !sameFlightFile(pending.path, event.Filename) {
+		return
+	}
+	e.resolve(pendingKey{kind: "flight_event", id: event.EventID}, event)
+}
+
+// failFlightPlanLoad fails the waiting flight plan load whose packet raised a LOAD_FLIGHTPLAN_FAILED exception
+func (e *Engine) failFlightPlanLoad(exception *types.ExceptionData) {
+	e.flightPacketMu.Lock()
+	defer e.flightPacketMu.Unlock()
+
+	e.mu.RLock()
+	pending := e.flightPending
+	var matches bool
+	if pending != nil {
+		matches = pending.sendID != 0 && pending.sendID == exception.SendID
+	}
+	e.mu.RUnlock()
+
+	if matches {
+		e.resolve(pendingKey{kind: "flight_event", id: pending.eventID}, fmt.Errorf("flight plan load failed: %s", exception.Description))
+	}
+}
+
+// sameFlightFile reports whether the filename of a flight event names the requested file.
+// The simulator reports absolute paths, so a relative request matches the trailing path elements;
+// case, separators and an extension added by the simulator are ignored.
+func sameFlightFile(requested string, reported string) bool {
+	normalize := func(p string) string {
+		return strings.TrimPrefix(strings.ToLower(strings.ReplaceAll(p, "/", `\`)), `.\`)
+	}
+	req, rep := normalize(requested), normalize(reported)
+	if req == "" || rep == "" {
+		return false
+	}
+
+	// SaveFlight appends .FLT to a name without extension
+	if !strings.Contains(req[strings.LastIndex(req, `\`)+1:], ".") {
+		if dot := strings.LastIndex(rep, "."); dot > strings.LastIndex(rep, `\`) {
+			rep = rep[:dot]
+		}
+	}
+	return rep == req || strings.HasSuffix(rep, `\`+req)
+}
@@ -0,0 +1,40 @@
+package client
+
+import "syscall"
+
+// SubscribeToFlowEvent subscribes to the flow events of MSFS 2024
+// Flow events arrive as "flow_event" messages; use types.IsFlowEvent to read them
+func (e *Engine) SubscribeToFlowEvent() error {
+	return e.callFlowEvent(SimConnect_SubscribeToFlowEvent, "SimConnect_SubscribeToFlowEvent")
+}
+
+// UnsubscribeToFlowEvent stops the flow events started with SubscribeToFlowEvent
+func (e *Engine) UnsubscribeToFlowEvent() error {
+	return e.callFlowEvent(SimConnect_UnsubscribeToFlowEvent, "SimConnect_UnsubscribeToFlowEvent")
+}
+
+// callFlowEvent issues one of the handle-only flow event calls
+func (e *Engine) callFlowEvent(proc *syscall.LazyProc, name string) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	// Get handle (thread-safe)
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	hresult, _ := e.call(proc, nil,
+		uintptr(handle), // hSimConnect
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: name, Code: uint32(hresult)}
+	}
+	return nil
+}
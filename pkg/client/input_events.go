@@ -0,0 +1,310 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// Input event hashes are 64-bit; SimConnect receives them as a single register-sized argument,
+// which matches the 64-bit SimConnect.dll shipped with MSFS.
+
+// EnumerateInputEvents requests the input events of the user aircraft
+// Pages arrive as "input_event_list" messages; the aggregated list follows as "input_event_list_complete"
+func (e *Engine) EnumerateInputEvents(requestID uint32) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	// Get handle and reset any previous pages for this request (thread-safe)
+	e.mu.Lock()
+	delete(e.inputEventPages, requestID)
+	handle := e.handle
+	e.mu.Unlock()
+
+	// Call SimConnect_EnumerateInputEvents
+	hresult, _ := e.call(SimConnect_EnumerateInputEvents, []any{requestID},
+		uintptr(handle),    // hSimConnect
+		uintptr(requestID), // RequestID
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: "SimConnect_EnumerateInputEvents", Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// InputEvents enumerates the input events of the user aircraft and waits for all pages; Listen() must be active
+func (e *Engine) InputEvents(ctx context.Context) ([]types.InputEventDescriptor, error) {
+	if err := e.ensureListening(); err != nil {
+		return nil, err
+	}
+
+	requestID := e.nextInternalID()
+	key := pendingKey{kind: "input_event_list", id: requestID}
+	ch := e.expect(key)
+
+	if err := e.EnumerateInputEvents(requestID); err != nil {
+		e.forget(key)
+		return nil, err
+	}
+
+	value, err := e.await(ctx, key, ch)
+
+	// One-shot request, its pages are no longer needed
+	e.mu.Lock()
+	delete(e.inputEventPages, requestID)
+	e.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	return value.(*types.InputEventList).Events, nil
+}
+
+// InputEventHash returns the hash of an input event seen in a previous enumeration
+func (e *Engine) InputEventHash(name string) (uint64, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	hash, exists := e.inputEventHashes[name]
+	return hash, exists
+}
+
+// RequestInputEvent requests the current value of an input event; the reply arrives as "input_event"
+func (e *Engine) RequestInputEvent(requestID uint32, hash uint64) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	// Get handle (thread-safe)
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	// Call SimConnect_GetInputEvent
+	hresult, _ := e.call(SimConnect_GetInputEvent, []any{requestID, hash},
+		uintptr(handle),    // hSimConnect
+		uintptr(requestID), // RequestID
+		uintptr(hash),      // Hash
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: "SimConnect_GetInputEvent", Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// GetInputEvent returns the current value of an input event; Listen() must be active
+// An unknown hash raises GET_INPUT_EVENT_FAILED, so callers should pass a context with a deadline
+func (e *Engine) GetInputEvent(ctx context.Context, hash uint64) (*types.InputEventValue, error) {
+	if err := e.ensureListening(); err != nil {
+		return nil, err
+	}
+
+	requestID := e.nextInternalID()
+	key := pendingKey{kind: "input_event", id: requestID}
+	ch := e.expect(key)
+
+	if err := e.RequestInputEvent(requestID, hash); err != nil {
+		e.forget(key)
+		return nil, err
+	}
+
+	value, err := e.await(ctx, key, ch)
+	if err != nil {
+		return nil, err
+	}
+	result := value.(*types.InputEventValue)
+	result.Hash = hash
+	return result, nil
+}
+
+// SetInputEvent sets an input event to a float64 (any numeric type) or string value
+func (e *Engine) SetInputEvent(hash uint64, value any) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	// Encode the value the way SimConnect expects it: a double or a null-terminated string
+	var (
+		ptr  unsafe.Pointer
+		size uintptr
+	)
+	switch v := value.(type) {
+	case string:
+		buf, err := syscall.BytePtrFromString(v)
+		if err != nil {
+			return fmt.Errorf("invalid input event value: %v", err)
+		}
+		ptr, size = unsafe.Pointer(buf), uintptr(len(v)+1)
+	default:
+		f, ok := toFloat64(value)
+		if !ok {
+			return fmt.Errorf("unsupported input event value type %T", value)
+		}
+		ptr, size = unsafe.Pointer(&f), unsafe.Sizeof(f)
+	}
+
+	// Get handle (thread-safe)
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	// Call SimConnect_SetInputEvent
+	hresult, _ := e.call(SimConnect_SetInputEvent, []any{hash, value},
+		uintptr(handle), // hSimConnect
+		uintptr(hash),   // Hash
+		size,            // cbUnitSize
+		uintptr(ptr),    // Value
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: "SimConnect_SetInputEvent", Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// SubscribeInputEvent requests change notifications for an input event (0 subscribes to all)
+// Changes arrive as "input_event" messages carrying the hash and new value
+func (e *Engine) SubscribeInputEvent(hash uint64) error {
+	return e.callInputEventHash(SimConnect_SubscribeInputEvent, "SimConnect_SubscribeInputEvent", hash)
+}
+
+// UnsubscribeInputEvent stops change notifications for an input event (0 unsubscribes from all)
+func (e *Engine) UnsubscribeInputEvent(hash uint64) error {
+	return e.callInputEventHash(SimConnect_UnsubscribeInputEvent, "SimConnect_UnsubscribeInputEvent", hash)
+}
+
+// EnumerateInputEventParams requests the parameter types of an input event; the reply arrives as "input_event_params"
+func (e *Engine) EnumerateInputEventParams(hash uint64) error {
+	return e.callInputEventHash(SimConnect_EnumerateInputEventParams, "SimConnect_EnumerateInputEventParams", hash)
+}
+
+// InputEventParams returns the parameter types of an input event; Listen() must be active
+func (e *Engine) InputEventParams(ctx context.Context, hash uint64) (*types.InputEventParams, error) {
+	if err := e.ensureListening(); err != nil {
+		return nil, err
+	}
+
+	// The reply carries no request ID, only the hash
+	key := pendingKey{kind: "input_event_params", id: foldHash(hash)}
+	ch := e.expect(key)
+
+	if err := e.EnumerateInputEventParams(hash); err != nil {
+		e.forget(key)
+		return nil, err
+	}
+
+	value, err := e.await(ctx, key, ch)
+	if err != nil {
+		return nil, err
+	}
+	return value.(*types.InputEventParams), nil
+}
+
+// callInputEventHash issues one of the (hash) input event calls
+func (e *Engine) callInputEventHash(proc *syscall.LazyProc, name string, hash uint64) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	// Get handle (thread-safe)
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	hresult, _ := e.call(proc, []any{hash},
+		uintptr(handle), // hSimConnect
+		uintptr(hash),   // Hash
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: name, Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// collectInputEventPage records descriptors and aggregates pages, returning the full list after the last page
+func (e *Engine) collectInputEventPage(page *types.InputEventList) *types.InputEventList {
+	e.mu.Lock()
+	for _, desc := range page.Events {
+		e.inputEventNames[desc.Hash] = desc.Name
+		e.inputEventHashes[desc.Name] = desc.Hash
+	}
+
+	events, done := e.inputEventPages.add(page.RequestID, page.Events, page.IsLastPage())
+	e.mu.Unlock()
+	if !done {
+		return nil
+	}
+
+	all := &types.InputEventList{
+		RequestID:   page.RequestID,
+		EntryNumber: page.EntryNumber,
+		OutOf:       page.OutOf,
+		Events:      events,
+	}
+	e.resolve(pendingKey{kind: "input_event_list", id: page.RequestID}, all)
+	return all
+}
+
+// inputEventName returns the name of an enumerated input event
+func (e *Engine) inputEventName(hash uint64) string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.inputEventNames[hash]
+}
+
+// foldHash reduces a 64-bit input event hash to a pending reply ID
+func foldHash(hash uint64) uint32 {
+	return uint32(hash) ^ uint32(hash>>32)
+}
+
+// toFloat64 converts numeric values to float64
+func toFloat64(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
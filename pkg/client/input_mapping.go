@@ -0,0 +1,202 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"syscall"
+	"unsafe"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// MapInputEventToClientEvent maps a keyboard or joystick input to client events
+// input uses SimConnect syntax, e.g. "VK_LCONTROL+A", "joystick:0:button:3" or "joystick:0:XAxis".
+// Pass SIMCONNECT_UNUSED as upEventID when no release event is wanted.
+func (e *Engine) MapInputEventToClientEvent(groupID types.InputGroupID, input string, downEventID types.ClientEventID, downValue uint32, upEventID types.ClientEventID, upValue uint32, maskable bool) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	inputPtr, err := syscall.BytePtrFromString(input)
+	if err != nil {
+		return fmt.Errorf("invalid input definition: %v", err)
+	}
+
+	maskableInt := 0
+	if maskable {
+		maskableInt = 1
+	}
+
+	// Get handle (thread-safe)
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	// Call SimConnect_MapInputEventToClientEvent
+	hresult, _ := e.call(SimConnect_MapInputEventToClientEvent, []any{groupID, input, downEventID, downValue, upEventID, upValue, maskable},
+		uintptr(handle),                   // hSimConnect
+		uintptr(groupID),                  // GroupID
+		uintptr(unsafe.Pointer(inputPtr)), // szInputDefinition
+		uintptr(downEventID),              // DownEventID
+		uintptr(downValue),                // DownValue
+		uintptr(upEventID),                // UpEventID
+		uintptr(upValue),                  // UpValue
+		uintptr(maskableInt),              // bMaskable
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: "SimConnect_MapInputEventToClientEvent", Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// SetInputGroupPriority sets the priority of an input group (use types.SIMCONNECT_GROUP_PRIORITY_* constants)
+func (e *Engine) SetInputGroupPriority(groupID types.InputGroupID, priority uint32) error {
+	return e.callInputGroup(SimConnect_SetInputGroupPriority, "SimConnect_SetInputGroupPriority", groupID, uintptr(priority))
+}
+
+// SetInputGroupState turns an input group on or off
+func (e *Engine) SetInputGroupState(groupID types.InputGroupID, state types.SimConnectState) error {
+	return e.callInputGroup(SimConnect_SetInputGroupState, "SimConnect_SetInputGroupState", groupID, uintptr(state))
+}
+
+// RemoveInputEvent removes an input definition from an input group
+func (e *Engine) RemoveInputEvent(groupID types.InputGroupID, input string) error {
+	inputPtr, err := syscall.BytePtrFromString(input)
+	if err != nil {
+		return fmt.Errorf("invalid input definition: %v", err)
+	}
+	if err := e.callInputGroup(SimConnect_RemoveInputEvent, "SimConnect_RemoveInputEvent", groupID, uintptr(unsafe.Pointer(inputPtr))); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	for id, binding := range e.inputBindings {
+		if binding.GroupID == groupID && binding.Input == input {
+			delete(e.inputBindings, id)
+		}
+	}
+	e.mu.Unlock()
+	return nil
+}
+
+// ClearInputGroup removes all input definitions from an input group
+func (e *Engine) ClearInputGroup(groupID types.InputGroupID) error {
+	if err := e.callInputGroup(SimConnect_ClearInputGroup, "SimConnect_ClearInputGroup", groupID); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	for id, binding := range e.inputBindings {
+		if binding.GroupID == groupID {
+			delete(e.inputBindings, id)
+		}
+	}
+	e.mu.Unlock()
+	return nil
+}
+
+// BindInput maps a keyboard chord, joystick button or axis to SDK-allocated client events and turns the input group on.
+// Presses arrive as "event" messages of kind "input" named after the input, releases with an " up" suffix;
+// axis positions are carried in EventData (see types.InputBinding.Axis).
+func (e *Engine) BindInput(groupID types.InputGroupID, input string) (*types.InputBinding, error) {
+	if input == "" {
+		return nil, fmt.Errorf("empty input definition")
+	}
+
+	binding := &types.InputBinding{
+		Input:       input,
+		GroupID:     groupID,
+		NotifyGroup: types.NotificationGroupID(e.nextInternalID()),
+		DownEventID: types.ClientEventID(e.nextInternalID()),
+		UpEventID:   types.ClientEventID(types.SIMCONNECT_UNUSED),
+	}
+	if !binding.IsAxis() {
+		binding.UpEventID = types.ClientEventID(e.nextInternalID())
+	}
+
+	// Private client events (no simulator event name) must be in a notification group to be received
+	events := []types.ClientEventID{binding.DownEventID}
+	if binding.UpEventID != types.ClientEventID(types.SIMCONNECT_UNUSED) {
+		events = append(events, binding.UpEventID)
+	}
+	for _, eventID := range events {
+		if err := e.MapClientEventToSimEvent(eventID, ""); err != nil {
+			return nil, err
+		}
+		if err := e.AddClientEventToNotificationGroup(binding.NotifyGroup, eventID, false); err != nil {
+			return nil, err
+		}
+	}
+	if err := e.SetNotificationGroupPriority(binding.NotifyGroup, types.SIMCONNECT_GROUP_PRIORITY_HIGHEST); err != nil {
+		return nil, err
+	}
+
+	if err := e.MapInputEventToClientEvent(groupID, input, binding.DownEventID, 0, binding.UpEventID, 0, false); err != nil {
+		return nil, err
+	}
+	if err := e.SetInputGroupState(groupID, types.SIMCONNECT_STATE_ON); err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	e.clientEvents[uint32(binding.DownEventID)] = &types.EventRegistration{EventID: uint32(binding.DownEventID), Name: input, Kind: types.EventKindInput}
+	if binding.UpEventID != types.ClientEventID(types.SIMCONNECT_UNUSED) {
+		e.clientEvents[uint32(binding.UpEventID)] = &types.EventRegistration{EventID: uint32(binding.UpEventID), Name: input + " up", Kind: types.EventKindInput}
+	}
+	e.groupNames[uint32(binding.NotifyGroup)] = fmt.Sprintf("input:%d", groupID)
+	e.inputBindings[uint32(binding.DownEventID)] = binding
+	e.mu.Unlock()
+
+	return binding, nil
+}
+
+// Unbind removes an input mapping created with BindInput
+func (e *Engine) Unbind(binding *types.InputBinding) error {
+	return e.RemoveInputEvent(binding.GroupID, binding.Input)
+}
+
+// InputBindings returns a snapshot of the active input bindings
+func (e *Engine) InputBindings() []types.InputBinding {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	bindings := make([]types.InputBinding, 0, len(e.inputBindings))
+	for _, binding := range e.inputBindings {
+		bindings = append(bindings, *binding)
+	}
+	sort.Slice(bindings, func(i, j int) bool { return bindings[i].DownEventID < bindings[j].DownEventID })
+	return bindings
+}
+
+// callInputGroup issues one of the (groupID, ...) input group calls; pointers in args stay alive until it returns
+//
+//go:uintptrescapes
+func (e *Engine) callInputGroup(proc *syscall.LazyProc, name string, groupID types.InputGroupID, args ...uintptr) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	// Get handle (thread-safe)
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	callArgs := append([]uintptr{uintptr(handle), uintptr(groupID)}, args...)
+	hresult, _ := e.call(proc, []any{groupID}, callArgs...)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: name, Code: uint32(hresult)}
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// InterceptAction is the decision an interceptor takes for a received event
+type InterceptAction int
+
+const (
+	InterceptPass    InterceptAction = iota // Retransmit the event to lower-priority groups and the simulator
+	InterceptSwallow                        // Drop the event; lower-priority groups and the simulator never see it
+)
+
+func (a InterceptAction) String() string {
+	switch a {
+	case InterceptPass:
+		return "pass"
+	case InterceptSwallow:
+		return "swallow"
+	default:
+		return "unknown"
+	}
+}
+
+// InterceptFunc decides what happens to an intercepted event.
+// It runs on the dispatch goroutine and must return quickly.
+type InterceptFunc func(event *types.EventData) InterceptAction
+
+// EventInterceptor is a registered interception of a simulator event
+type EventInterceptor struct {
+	Event    types.KEvent              // Normalized simulator event name
+	EventID  types.ClientEventID       // SDK-allocated client event ID
+	GroupID  types.NotificationGroupID // SDK-allocated maskable notification group
+	Priority uint32                    // Priority of the group
+	handler  InterceptFunc
+}
+
+// interceptIDs are the client event and group of an interception. SimConnect cannot unmap a client
+// event, so once mapped they are kept for the next interception of the same event.
+type interceptIDs struct {
+	eventID types.ClientEventID
+	groupID types.NotificationGroupID
+}
+
+// InterceptEvent receives a simulator event before lower-priority clients and the simulator itself.
+// priority must be in the maskable range (SIMCONNECT_GROUP_PRIORITY_HIGHEST_MASKABLE or lower priority,
+// i.e. a higher number) so the event can be swallowed. The handler decides per event whether it is
+// passed on, which retransmits it just below priority, or swallowed.
+// Intercepted events still arrive on the Listen() channel, with "intercepted" set to the action taken.
+func (e *Engine) InterceptEvent(event types.KEvent, priority uint32, handler InterceptFunc) (*EventInterceptor, error) {
+	if handler == nil {
+		return nil, fmt.Errorf("interceptor handler is nil")
+	}
+	if priority < types.SIMCONNECT_GROUP_PRIORITY_HIGHEST_MASKABLE || priority == types.SIMCONNECT_GROUP_PRIORITY_LOWEST {
+		return nil, fmt.Errorf("priority %d cannot mask events (use %d to %d)", priority,
+			types.SIMCONNECT_GROUP_PRIORITY_HIGHEST_MASKABLE, types.SIMCONNECT_GROUP_PRIORITY_LOWEST-1)
+	}
+
+	name := event.Normalize()
+	if name == "" {
+		return nil, fmt.Errorf("empty event name")
+	}
+
+	ids, mapped := e.takeInterceptIDs(name)
+	if !mapped {
+		ids = interceptIDs{
+			eventID: types.ClientEventID(e.nextInternalID()),
+			groupID: types.NotificationGroupID(e.nextInternalID()),
+		}
+		if err := e.MapClientEventToSimEvent(ids.eventID, string(name)); err != nil {
+			return nil, err
+		}
+	}
+
+	ic := &EventInterceptor{
+		Event:    name,
+		EventID:  ids.eventID,
+		GroupID:  ids.groupID,
+		Priority: priority,
+		handler:  handler,
+	}
+
+	if err := e.AddClientEventToNotificationGroup(ic.GroupID, ic.EventID, true); err != nil {
+		e.releaseInterceptIDs(name, ids)
+		return nil, err
+	}
+	if err := e.SetNotificationGroupPriority(ic.GroupID, priority); err != nil {
+		e.releaseInterceptIDs(name, ids)
+		return nil, err
+	}
+	e.NameNotificationGroup(ic.GroupID, "intercept:"+string(name))
+
+	e.mu.Lock()
+	e.interceptors[uint32(ic.EventID)] = ic
+	e.mu.Unlock()
+
+	return ic, nil
+}
+
+// RemoveInterceptor stops intercepting an event; it is no longer masked from lower-priority clients
+func (e *Engine) RemoveInterceptor(ic *EventInterceptor) error {
+	e.mu.Lock()
+	delete(e.interceptors, uint32(ic.EventID))
+	e.mu.Unlock()
+
+	return e.releaseInterceptIDs(ic.Event, interceptIDs{eventID: ic.EventID, groupID: ic.GroupID})
+}
+
+// takeInterceptIDs returns IDs already mapped to an event by a removed or failed interception
+func (e *Engine) takeInterceptIDs(name types.KEvent) (interceptIDs, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	spare := e.spareIntercepts[name]
+	if len(spare) == 0 {
+		return interceptIDs{}, false
+	}
+	ids := spare[len(spare)-1]
+	if len(spare) == 1 {
+		delete(e.spareIntercepts, name)
+	} else {
+		e.spareIntercepts[name] = spare[:len(spare)-1]
+	}
+	return ids, true
+}
+
+// releaseInterceptIDs takes the client event of an interception out of its group and keeps the IDs
+// for the next interception of the event. The removal fails harmlessly when the event never made
+// it into the group.
+func (e *Engine) releaseInterceptIDs(name types.KEvent, ids interceptIDs) error {
+	err := e.RemoveClientEvent(ids.groupID, ids.eventID)
+
+	e.mu.Lock()
+	e.spareIntercepts[name] = append(e.spareIntercepts[name], ids)
+	e.mu.Unlock()
+
+	return err
+}
+
+// RemoveClientEvent removes a client event from a notification group
+func (e *Engine) RemoveClientEvent(groupID types.NotificationGroupID, eventID types.ClientEventID) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	// Get handle (thread-safe)
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	// Call SimConnect_RemoveClientEvent
+	hresult, _ := e.call(SimConnect_RemoveClientEvent, []any{groupID, eventID},
+		uintptr(handle),  // hSimConnect
+		uintptr(groupID), // GroupID
+		uintptr(eventID), // EventID
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: "SimConnect_RemoveClientEvent", Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// intercept runs the interceptor registered for a received event, if any, and applies its decision
+// A failed retransmission of a passed event is returned as the error
+func (e *Engine) intercept(event *types.EventData) (InterceptAction, bool, error) {
+	e.mu.RLock()
+	ic, exists := e.interceptors[event.EventID]
+	e.mu.RUnlock()
+
+	if !exists {
+		return 0, false, nil
+	}
+
+	action := ic.handler(event)
+	if action != InterceptPass {
+		return action, true, nil
+	}
+
+	// Retransmit just below our own priority so the event skips this group and reaches the rest
+	err := e.TransmitClientEvent(types.SIMCONNECT_OBJECT_ID_USER, ic.EventID, event.EventData,
+		types.NotificationGroupID(ic.Priority+1), types.SIMCONNECT_EVENT_FLAG_GROUPID_IS_PRIORITY)
+	return action, true, err
+}
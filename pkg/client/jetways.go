@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// RequestJetwayData requests the state of the jetways serving the given parking spots of an airport
+// Pages arrive as "jetway_data" messages; the aggregated list follows as "jetway_data_complete"
+func (e *Engine) RequestJetwayData(airportICAO string, parkingIndices []int32) error {
+	if len(parkingIndices) == 0 {
+		return fmt.Errorf("no parking indices given")
+	}
+
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	icaoPtr, err := syscall.BytePtrFromString(airportICAO)
+	if err != nil {
+		return fmt.Errorf("invalid airport ICAO: %v", err)
+	}
+
+	// Get handle and reset any previous pages (thread-safe)
+	e.mu.Lock()
+	clear(e.jetwayPages)
+	handle := e.handle
+	e.mu.Unlock()
+
+	// Call SimConnect_RequestJetwayData
+	hresult, _ := e.call(SimConnect_RequestJetwayData, []any{airportICAO, parkingIndices},
+		uintptr(handle),                             // hSimConnect
+		uintptr(unsafe.Pointer(icaoPtr)),            // szAirportIcao
+		uintptr(len(parkingIndices)),                // dwArrayCount
+		uintptr(unsafe.Pointer(&parkingIndices[0])), // indexes
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: "SimConnect_RequestJetwayData", Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// Jetways requests jetway data and waits for all pages; Listen() must be active
+// A JETWAY_DATA exception, e.g. for an unknown airport, fails the call
+func (e *Engine) Jetways(ctx context.Context, airportICAO string, parkingIndices []int32) ([]types.JetwayData, error) {
+	if err := e.ensureListening(); err != nil {
+		return nil, err
+	}
+
+	// The call carries no request ID, so requests are serialized
+	e.jetwayMu.Lock()
+	defer e.jetwayMu.Unlock()
+
+	key := pendingKey{kind: "jetway_data"}
+	ch := e.expect(key)
+
+	if err := e.RequestJetwayData(airportICAO, parkingIndices); err != nil {
+		e.forget(key)
+		return nil, err
+	}
+
+	value, err := e.await(ctx, key, ch)
+	if err != nil {
+		return nil, err
+	}
+	return value.(*types.JetwayList).Jetways, nil
+}
+
+// collectJetwayPage aggregates jetway pages, returning the full list after the last page
+func (e *Engine) collectJetwayPage(page *types.JetwayList) *types.JetwayList {
+	e.mu.Lock()
+	items, done := e.jetwayPages.add(page.RequestID, page.Jetways, page.IsLastPage())
+	e.mu.Unlock()
+	if !done {
+		return nil
+	}
+
+	all := &types.JetwayList{
+		RequestID:   page.RequestID,
+		EntryNumber: page.EntryNumber,
+		OutOf:       page.OutOf,
+		Jetways:     items,
+	}
+	e.resolve(pendingKey{kind: "jetway_data"}, all)
+	return all
+}
+
+// failJetwayRequest fails a waiting Jetways call after a JETWAY_DATA exception
+func (e *Engine) failJetwayRequest(exception *types.ExceptionData) {
+	e.mu.Lock()
+	clear(e.jetwayPages)
+	e.mu.Unlock()
+
+	e.resolve(pendingKey{kind: "jetway_data"}, fmt.Errorf("jetway data request failed: %s", exception.Description))
+}
@@ -2,7 +2,9 @@ package client
 
 import (
 	"syscall"
+	"time"
 
+	"github.com/mycrew-online/sdk/pkg/facilities"
 	"github.com/mycrew-online/sdk/pkg/types"
 )
 
@@ -10,8 +12,18 @@ const (
 	DLL_DEFAULT_PATH = "C:/MSFS 2024 SDK/SimConnect SDK/lib/SimConnect.dll"
 	// Default buffer size for the message stream channel
 	DEFAULT_STREAM_BUFFER_SIZE = 100
+	// Number of sent packets kept to trace exceptions back to their call
+	DEFAULT_PACKET_HISTORY_SIZE = 256
+	// Time the *AndVerify methods wait for an exception when the context has no deadline
+	DEFAULT_VERIFY_TIMEOUT = 500 * time.Millisecond
+	// IDs at or above this value are allocated by the SDK for its own requests,
+	// definitions and events; application IDs should stay below it.
+	SDK_INTERNAL_ID_BASE = uint32(0xF0000000)
 )
 
+// New creates a connection using the default SimConnect DLL.
+//
+// Deprecated: use Load, which reports a DLL that cannot be loaded up front instead of at Open.
 func New(name string) Connection {
 	return NewWithCustomDLL(
 		name,
@@ -19,7 +31,30 @@ func New(name string) Connection {
 	)
 }
 
+// NewWithCustomDLL creates a connection using the SimConnect DLL at path.
+//
+// Deprecated: use LoadWithCustomDLL, which reports a DLL that cannot be loaded up front instead of at Open.
 func NewWithCustomDLL(name string, path string) Connection {
+	return newEngine(name, path)
+}
+
+// Load creates a connection using the default SimConnect DLL, returning an error wrapping
+// ErrDLLNotLoaded when the DLL cannot be loaded or lacks the core exports
+func Load(name string) (Connection, error) {
+	return LoadWithCustomDLL(name, DLL_DEFAULT_PATH)
+}
+
+// LoadWithCustomDLL creates a connection using the SimConnect DLL at path, returning an error
+// wrapping ErrDLLNotLoaded when the DLL cannot be loaded or lacks the core exports
+func LoadWithCustomDLL(name string, path string) (Connection, error) {
+	client := newEngine(name, path)
+	if client.loadErr != nil {
+		return nil, client.loadErr
+	}
+	return client, nil
+}
+
+func newEngine(name string, path string) *Engine {
 	state := &SystemState{
 		IsConnected: false,
 	}
@@ -27,14 +62,36 @@ func NewWithCustomDLL(name string, path string) Connection {
 		dll:                   dll(path),
 		name:                  name,
 		system:                state,
-		stream:                make(chan any, DEFAULT_STREAM_BUFFER_SIZE), // Buffered channel for message processing
-		dataTypeRegistry:      make(map[uint32]types.SimConnectDataType),  // Initialize data type tracking
-		unhandledMessageStats: make(map[types.SimConnectRecvID]int64),     // Initialize unhandled message tracking
-		lastUnhandledCheck:    0,                                          // Initialize timestamp for unhandled message monitoring
+		stream:                make(chan any, DEFAULT_STREAM_BUFFER_SIZE),      // Buffered channel for message processing
+		dataTypeRegistry:      make(map[uint32]types.SimConnectDataType),       // Initialize data type tracking
+		unhandledMessageStats: make(map[types.SimConnectRecvID]int64),          // Initialize unhandled message tracking
+		lastUnhandledCheck:    0,                                               // Initialize timestamp for unhandled message monitoring
+		pending:               make(map[pendingKey]chan any),                   // Initialize pending reply tracking
+		facilityDefinitions:   make(map[uint32]*facilities.Definition),         // Initialize facility definition layouts
+		facilityRequests:      make(map[uint32]*facilities.Assembler),          // Initialize in-flight facility requests
+		facilityListTypes:     make(map[uint32]types.FacilityListType),         // Initialize facility list request types
+		facilityListPages:     make(map[uint32]*types.FacilityListData),        // Initialize facility list page aggregation
+		inputEventPages:       make(listPages[types.InputEventDescriptor]),     // Initialize input event enumeration pages
+		inputEventNames:       make(map[uint64]string),                         // Initialize input event hash → name lookup
+		inputEventHashes:      make(map[string]uint64),                         // Initialize input event name → hash lookup
+		systemEvents:          make(map[uint32]*types.SystemEventSubscription), // Initialize system event registry
+		flightEvents:          make(map[string]uint32),                         // Initialize flight confirmation events
+		clientEvents:          make(map[uint32]*types.EventRegistration),       // Initialize client event name registry
+		clientDataDefinitions: make(map[uint32]*types.ClientDataDefinition),    // Initialize client data layouts
+		liveryPages:           make(listPages[types.SimObjectLivery]),          // Initialize SimObject and livery enumeration pages
+		controllerPages:       make(listPages[types.ControllerItem]),           // Initialize controller enumeration pages
+		jetwayPages:           make(listPages[types.JetwayData]),               // Initialize jetway data pages
+		inputBindings:         make(map[uint32]*types.InputBinding),            // Initialize input bindings
+		packets:               newPacketRing(DEFAULT_PACKET_HISTORY_SIZE),      // Initialize sent packet history
+		actionPackets:         make(map[uint32]pendingAction),                  // Initialize action packet tracking
+		interceptors:          make(map[uint32]*EventInterceptor),              // Initialize event interceptors
+		spareIntercepts:       make(map[types.KEvent][]interceptIDs),           // Initialize reusable interception IDs
+		keyEvents:             make(map[types.KEvent]types.ClientEventID),      // Initialize key event mappings
+		groupNames:            make(map[uint32]string),                         // Initialize notification group names
 	}
 
-	// TODO Error handling for DLL loading???
-	client.bootstrap()
+	// Keep the loading error for Open and LoadWithCustomDLL
+	client.loadErr = client.bootstrap()
 
 	return client
 }
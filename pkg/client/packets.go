@@ -0,0 +1,223 @@
+package client
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// clientPackage prefixes the function names of this package in stack frames
+const clientPackage = "github.com/mycrew-online/sdk/pkg/client."
+
+// packetRing is a bounded history of sent packets; the oldest entry is overwritten first
+type packetRing struct {
+	mu         sync.Mutex
+	entries    []types.PacketContext
+	exceptions []*types.ExceptionData // Exception raised by the packet in the same slot, if any
+	next       int                    // Slot written next
+	full       bool                   // Every slot holds an entry
+}
+
+func newPacketRing(size int) *packetRing {
+	return &packetRing{
+		entries:    make([]types.PacketContext, size),
+		exceptions: make([]*types.ExceptionData, size),
+	}
+}
+
+// add records a packet
+func (r *packetRing) add(packet types.PacketContext) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[r.next] = packet
+	r.exceptions[r.next] = nil
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// fail attaches the packet that raised an exception to it and records the exception,
+// if the packet is still in the history
+func (r *packetRing) fail(exception *types.ExceptionData) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	slot := r.slot(exception.SendID)
+	if slot < 0 {
+		return false
+	}
+	packet := r.entries[slot]
+	exception.Packet = &packet
+	exception.Field = packet.Field
+	r.exceptions[slot] = exception
+	return true
+}
+
+// exception returns the exception recorded for a packet, if any
+func (r *packetRing) exception(sendID uint32) *types.ExceptionData {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if slot := r.slot(sendID); slot >= 0 {
+		return r.exceptions[slot]
+	}
+	return nil
+}
+
+// slot returns the index of the packet with the given send ID, or -1; r.mu must be held
+func (r *packetRing) slot(sendID uint32) int {
+	count := r.next
+	if r.full {
+		count = len(r.entries)
+	}
+	for i := 0; i < count; i++ {
+		if r.entries[i].SendID == sendID {
+			return i
+		}
+	}
+	return -1
+}
+
+// snapshot returns the recorded packets, oldest first
+func (r *packetRing) snapshot() []types.PacketContext {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]types.PacketContext(nil), r.ordered()...)
+}
+
+// ordered returns the entries oldest first; r.mu must be held
+func (r *packetRing) ordered() []types.PacketContext {
+	if !r.full {
+		return r.entries[:r.next]
+	}
+	return append(append([]types.PacketContext(nil), r.entries[r.next:]...), r.entries[:r.next]...)
+}
+
+// call issues a SimConnect call and records the packet it sent, so exceptions can be traced back to it.
+// args describes the call for the history; callArgs are passed to the procedure.
+// It returns the HRESULT and the packet ID (0 when the call failed or the ID is unavailable).
+// Like LazyProc.Call, it keeps pointers converted to uintptr in callArgs alive and in place until it returns.
+//
+//go:uintptrescapes
+func (e *Engine) call(proc *syscall.LazyProc, args []any, callArgs ...uintptr) (uintptr, uint32) {
+	return e.callField(proc, "", args, callArgs...)
+}
+
+// callField is call for packets that add a definition field, which exceptions are attributed to.
+// The exception Index is the 1-based parameter index of the call, so a packet carries at most one field.
+//
+//go:uintptrescapes
+func (e *Engine) callField(proc *syscall.LazyProc, field string, args []any, callArgs ...uintptr) (uintptr, uint32) {
+	// An export missing from this DLL (e.g. an MSFS 2024 call on MSFS 2020) fails instead of panicking
+	if proc.Find() != nil {
+		return uintptr(E_NOTIMPL), 0
+	}
+
+	// The packet ID must be read right after the send, before another goroutine sends
+	e.sendMu.Lock()
+	hresult, _, _ := proc.Call(callArgs...)
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		e.sendMu.Unlock()
+		return hresult, 0
+	}
+	sendID, err := lastSentPacketID(callArgs[0]) // Every traced call passes hSimConnect first
+	e.sendMu.Unlock()
+
+	if err != nil {
+		return hresult, 0
+	}
+
+	method, caller := callSite()
+	e.packets.add(types.PacketContext{
+		SendID:   sendID,
+		Function: proc.Name,
+		Method:   method,
+		Args:     args,
+		Field:    field,
+		Caller:   caller,
+	})
+	return hresult, sendID
+}
+
+// GetLastSentPacketID returns the ID of the last packet sent to the simulator, as reported in
+// the SendID of exceptions
+func (e *Engine) GetLastSentPacketID() (uint32, error) {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return 0, ErrNotConnected
+	}
+
+	// Get handle (thread-safe)
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	e.sendMu.Lock()
+	defer e.sendMu.Unlock()
+	return lastSentPacketID(uintptr(handle))
+}
+
+// PacketHistory returns the recently sent packets, oldest first
+func (e *Engine) PacketHistory() []types.PacketContext {
+	return e.packets.snapshot()
+}
+
+// lastSentPacketID returns the ID of the last packet sent on a SimConnect handle
+func lastSentPacketID(handle uintptr) (uint32, error) {
+	var sendID uint32
+	// Call SimConnect_GetLastSentPacketID
+	hresult, _, _ := SimConnect_GetLastSentPacketID.Call(
+		handle,                           // hSimConnect
+		uintptr(unsafe.Pointer(&sendID)), // pdwSendID
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return 0, &HRESULTError{Function: "SimConnect_GetLastSentPacketID", Code: uint32(hresult)}
+	}
+	return sendID, nil
+}
+
+// annotateException attaches the call that sent the offending packet, and the definition field
+// its index refers to, to an exception
+func (e *Engine) annotateException(exception *types.ExceptionData) {
+	if !e.packets.fail(exception) {
+		return
+	}
+
+	// Recorded before resolving, so a verify registering late still finds it (see verify)
+	e.resolve(pendingKey{kind: "exception", id: exception.SendID}, exception)
+}
+
+// callSite returns the outermost Engine method on the stack and the file:line of its caller
+func callSite() (method string, caller string) {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs) // Skip runtime.Callers, callSite and callField
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, clientPackage) {
+			caller = fmt.Sprintf("%s:%d", frame.File, frame.Line)
+			break
+		}
+		if name := frame.Function[strings.LastIndex(frame.Function, ".")+1:]; name != "" && name[0] >= 'A' && name[0] <= 'Z' {
+			method = name
+		}
+		if !more {
+			break
+		}
+	}
+	return method, caller
+}
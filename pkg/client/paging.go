@@ -0,0 +1,56 @@
+package client
+
+import (
+	"unsafe"
+
+	"github.com/mycrew-online/sdk/internal/wire"
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// listHeader is the page position of a reply built on SIMCONNECT_RECV_LIST_TEMPLATE
+type listHeader struct {
+	requestID   uint32
+	entryNumber uint32 // Index of this page
+	outOf       uint32 // Total number of pages
+}
+
+// decodeListPage reads the SIMCONNECT_RECV_LIST_TEMPLATE header of a paged reply and decodes its
+// DwArraySize items of size bytes each. ok is false when the reply is too short for the header
+// and all of its items.
+func decodeListPage[T any](ppData uintptr, pcbData uint32, size int, decode func(r *wire.Reader) T) (header listHeader, items []T, ok bool) {
+	headerSize := unsafe.Sizeof(types.SIMCONNECT_RECV_LIST_TEMPLATE{})
+	if ppData == 0 || pcbData < uint32(headerSize) {
+		return listHeader{}, nil, false
+	}
+
+	list := (*types.SIMCONNECT_RECV_LIST_TEMPLATE)(unsafe.Pointer(ppData))
+	if uint64(pcbData) < uint64(headerSize)+uint64(list.DwArraySize)*uint64(size) {
+		return listHeader{}, nil, false
+	}
+	header = listHeader{
+		requestID:   list.DwRequestID,
+		entryNumber: list.DwEntryNumber,
+		outOf:       list.DwOutOf,
+	}
+
+	payload := copyPayload(ppData, pcbData, headerSize)
+	for i := 0; i < int(list.DwArraySize); i++ {
+		items = append(items, decode(wire.NewReader(payload[i*size:(i+1)*size])))
+	}
+	return header, items, true
+}
+
+// listPages aggregates the items of paged replies per request ID; guarded by Engine.mu
+type listPages[T any] map[uint32][]T
+
+// add appends the items of a page to those received for its request. After the last page it returns
+// them all and forgets the request, so the next enumeration with the same ID starts over.
+func (p listPages[T]) add(requestID uint32, items []T, last bool) ([]T, bool) {
+	all := append(p[requestID], items...)
+	if !last {
+		p[requestID] = all
+		return nil, false
+	}
+	delete(p, requestID)
+	return all, true
+}
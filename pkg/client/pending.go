@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// pendingKey identifies a reply the SDK is waiting for.
+// kind separates the ID spaces of different SimConnect reply types.
+type pendingKey struct {
+	kind string
+	id   uint32
+}
+
+// nextInternalID allocates an ID from the range reserved for the SDK itself.
+// It is used for request, definition and event IDs created on behalf of the caller.
+func (e *Engine) nextInternalID() uint32 {
+	return SDK_INTERNAL_ID_BASE + atomic.AddUint32(&e.internalIDs, 1)
+}
+
+// expect registers interest in a reply before the request is sent, so a fast
+// reply arriving on the dispatch goroutine cannot be missed.
+func (e *Engine) expect(key pendingKey) chan any {
+	ch := make(chan any, 1)
+
+	e.pendingMu.Lock()
+	e.pending[key] = ch
+	e.pendingMu.Unlock()
+
+	return ch
+}
+
+// forget drops a pending reply registration
+func (e *Engine) forget(key pendingKey) {
+	e.pendingMu.Lock()
+	delete(e.pending, key)
+	e.pendingMu.Unlock()
+}
+
+// resolve hands a reply to the goroutine waiting for it, if any
+func (e *Engine) resolve(key pendingKey, value any) bool {
+	e.pendingMu.Lock()
+	ch, exists := e.pending[key]
+	if exists {
+		delete(e.pending, key)
+	}
+	e.pendingMu.Unlock()
+
+	if !exists {
+		return false
+	}
+	ch <- value // Buffered with capacity 1, never blocks
+	return true
+}
+
+// await blocks until the reply for key arrives or ctx is done.
+// A reply that is itself an error is returned as the error.
+func (e *Engine) await(ctx context.Context, key pendingKey, ch chan any) (any, error) {
+	select {
+	case value := <-ch:
+		if err, ok := value.(error); ok {
+			return nil, err
+		}
+		return value, nil
+	case <-ctx.Done():
+		e.forget(key)
+		return nil, ctx.Err()
+	}
+}
+
+// ensureListening verifies that the dispatch loop is running, since replies
+// awaited by the context-aware methods are only delivered from there.
+func (e *Engine) ensureListening() error {
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	e.mu.RLock()
+	listening := e.isListening
+	e.mu.RUnlock()
+
+	if !listening {
+		return fmt.Errorf("not listening - call Listen() before waiting for replies")
+	}
+	return nil
+}
@@ -1,6 +1,10 @@
 package client
 
-import "syscall"
+import (
+	"fmt"
+	"strings"
+	"syscall"
+)
 
 var (
 	SimConnect_Open                              *syscall.LazyProc // SimConnect_Open procedure
@@ -13,56 +17,179 @@ var (
 	SimConnect_SetDataOnSimObject                *syscall.LazyProc // SimConnect_SetDataOnSimObject procedure
 	SimConnect_SubscribeToSystemEvent            *syscall.LazyProc // SimConnect_SubscribeToSystemEvent procedure
 	SimConnect_SetSystemEventState               *syscall.LazyProc // SimConnect_SetSystemEventState procedure
+	SimConnect_UnsubscribeFromSystemEvent        *syscall.LazyProc // SimConnect_UnsubscribeFromSystemEvent procedure
 	SimConnect_EnumerateInputEvents              *syscall.LazyProc // SimConnect_EnumerateInputEvents procedure
 	SimConnect_SubscribeInputEvent               *syscall.LazyProc // SimConnect_SubscribeInputEvents procedure
+	SimConnect_UnsubscribeInputEvent             *syscall.LazyProc // SimConnect_UnsubscribeInputEvent procedure
+	SimConnect_GetInputEvent                     *syscall.LazyProc // SimConnect_GetInputEvent procedure
+	SimConnect_SetInputEvent                     *syscall.LazyProc // SimConnect_SetInputEvent procedure
+	SimConnect_EnumerateInputEventParams         *syscall.LazyProc // SimConnect_EnumerateInputEventParams procedure
 	SimConnect_MapClientEventToSimEvent          *syscall.LazyProc // SimConnect_MapClientEventToSimEvent procedure
 	SimConnect_TransmitClientEvent               *syscall.LazyProc // SimConnect_TransmitClientEvent procedure
+	SimConnect_TransmitClientEvent_EX1           *syscall.LazyProc // SimConnect_TransmitClientEvent_EX1 procedure
 	SimConnect_AddClientEventToNotificationGroup *syscall.LazyProc // SimConnect_AddClientEventToNotificationGroup procedure
 	SimConnect_SetNotificationGroupPriority      *syscall.LazyProc // SimConnect_SetNotificationGroupPriority procedure
+	SimConnect_RemoveClientEvent                 *syscall.LazyProc // SimConnect_RemoveClientEvent procedure
+	SimConnect_MapInputEventToClientEvent        *syscall.LazyProc // SimConnect_MapInputEventToClientEvent procedure
+	SimConnect_SetInputGroupPriority             *syscall.LazyProc // SimConnect_SetInputGroupPriority procedure
+	SimConnect_SetInputGroupState                *syscall.LazyProc // SimConnect_SetInputGroupState procedure
+	SimConnect_RemoveInputEvent                  *syscall.LazyProc // SimConnect_RemoveInputEvent procedure
+	SimConnect_ClearInputGroup                   *syscall.LazyProc // SimConnect_ClearInputGroup procedure
+	SimConnect_EnumerateControllers              *syscall.LazyProc // SimConnect_EnumerateControllers procedure
+	SimConnect_MapClientDataNameToID             *syscall.LazyProc // SimConnect_MapClientDataNameToID procedure
+	SimConnect_CreateClientData                  *syscall.LazyProc // SimConnect_CreateClientData procedure
+	SimConnect_AddToClientDataDefinition         *syscall.LazyProc // SimConnect_AddToClientDataDefinition procedure
+	SimConnect_ClearClientDataDefinition         *syscall.LazyProc // SimConnect_ClearClientDataDefinition procedure
+	SimConnect_RequestClientData                 *syscall.LazyProc // SimConnect_RequestClientData procedure
+	SimConnect_SetClientData                     *syscall.LazyProc // SimConnect_SetClientData procedure
+	SimConnect_AddToFacilityDefinition           *syscall.LazyProc // SimConnect_AddToFacilityDefinition procedure
+	SimConnect_RequestFacilityData               *syscall.LazyProc // SimConnect_RequestFacilityData procedure
+	SimConnect_RequestFacilitiesList             *syscall.LazyProc // SimConnect_RequestFacilitiesList procedure
+	SimConnect_RequestFacilitiesList_EX1         *syscall.LazyProc // SimConnect_RequestFacilitiesList_EX1 procedure
+	SimConnect_SubscribeToFacilities             *syscall.LazyProc // SimConnect_SubscribeToFacilities procedure
+	SimConnect_SubscribeToFacilities_EX1         *syscall.LazyProc // SimConnect_SubscribeToFacilities_EX1 procedure
+	SimConnect_UnsubscribeToFacilities           *syscall.LazyProc // SimConnect_UnsubscribeToFacilities procedure
+	SimConnect_UnsubscribeToFacilities_EX1       *syscall.LazyProc // SimConnect_UnsubscribeToFacilities_EX1 procedure
+	SimConnect_FlightLoad                        *syscall.LazyProc // SimConnect_FlightLoad procedure
+	SimConnect_FlightSave                        *syscall.LazyProc // SimConnect_FlightSave procedure
+	SimConnect_FlightPlanLoad                    *syscall.LazyProc // SimConnect_FlightPlanLoad procedure
+	SimConnect_ExecuteAction                     *syscall.LazyProc // SimConnect_ExecuteAction procedure
+	SimConnect_RequestJetwayData                 *syscall.LazyProc // SimConnect_RequestJetwayData procedure
+	SimConnect_EnumerateSimObjectsAndLiveries    *syscall.LazyProc // SimConnect_EnumerateSimObjectsAndLiveries procedure
+	SimConnect_SubscribeToFlowEvent              *syscall.LazyProc // SimConnect_SubscribeToFlowEvent procedure
+	SimConnect_UnsubscribeToFlowEvent            *syscall.LazyProc // SimConnect_UnsubscribeToFlowEvent procedure
+	SimConnect_GetLastSentPacketID               *syscall.LazyProc // SimConnect_GetLastSentPacketID procedure
 )
 
 func (e *Engine) bootstrap() error {
+	// Load the DLL up front, so a wrong path is reported instead of panicking on the first call
+	if err := e.dll.Load(); err != nil {
+		return fmt.Errorf("%w: %v", ErrDLLNotLoaded, err)
+	}
+
 	// Load the procedures from the SimConnect DLL to make them available for use.
 	e.loadProcedures()
-	// Here we would implement the logic to initialize the processes.
-	// This might involve loading process information from the SimConnect server, setting up any necessary event handlers, etc.
-	// For now, we will just return nil to indicate success.
+
+	// Probe which exports the DLL provides; optional ones only disable their features
+	e.capabilities = probeCapabilities(e.dll.Name, e.procedures)
+	if missing := e.capabilities.missingRequired(); len(missing) > 0 {
+		return fmt.Errorf("%w: missing exports %s", ErrDLLNotLoaded, strings.Join(missing, ", "))
+	}
 	return nil
 }
 
+// newProc declares a procedure of the DLL and records it for capability probing
+func (e *Engine) newProc(name string) *syscall.LazyProc {
+	proc := e.dll.NewProc(name)
+	e.procedures = append(e.procedures, proc)
+	return proc
+}
+
 func (e *Engine) loadProcedures() error {
 	// SimConnect_Open procedure
-	SimConnect_Open = e.dll.NewProc("SimConnect_Open")
+	SimConnect_Open = e.newProc("SimConnect_Open")
 	// SimConnect_Close procedure
-	SimConnect_Close = e.dll.NewProc("SimConnect_Close")
+	SimConnect_Close = e.newProc("SimConnect_Close")
 	// SimConnect_GetNextDispatch procedure
-	SimConnect_GetNextDispatch = e.dll.NewProc("SimConnect_GetNextDispatch")
+	SimConnect_GetNextDispatch = e.newProc("SimConnect_GetNextDispatch")
 	// SimConnect_AddToDataDefinition procedure
-	SimConnect_AddToDataDefinition = e.dll.NewProc("SimConnect_AddToDataDefinition")
+	SimConnect_AddToDataDefinition = e.newProc("SimConnect_AddToDataDefinition")
 	// SimConnect_RequestDataOnSimObject procedure
-	SimConnect_RequestDataOnSimObject = e.dll.NewProc("SimConnect_RequestDataOnSimObject")
+	SimConnect_RequestDataOnSimObject = e.newProc("SimConnect_RequestDataOnSimObject")
 	// SimConnect_ClearDataDefinition procedure
-	SimConnect_ClearDataDefinition = e.dll.NewProc("SimConnect_ClearDataDefinition")
+	SimConnect_ClearDataDefinition = e.newProc("SimConnect_ClearDataDefinition")
 	// SimConnect_RequestSystemState procedure
-	SimConnect_RequestSystemState = e.dll.NewProc("SimConnect_RequestSystemState")
+	SimConnect_RequestSystemState = e.newProc("SimConnect_RequestSystemState")
 	// SimConnect_SetDataOnSimObject procedure
-	SimConnect_SetDataOnSimObject = e.dll.NewProc("SimConnect_SetDataOnSimObject")
+	SimConnect_SetDataOnSimObject = e.newProc("SimConnect_SetDataOnSimObject")
 	// SimConnect_SubscribeToSystemEvent procedure
-	SimConnect_SubscribeToSystemEvent = e.dll.NewProc("SimConnect_SubscribeToSystemEvent")
+	SimConnect_SubscribeToSystemEvent = e.newProc("SimConnect_SubscribeToSystemEvent")
 	// SimConnect_SetSystemEventState procedure
-	SimConnect_SetSystemEventState = e.dll.NewProc("SimConnect_SetSystemEventState")
+	SimConnect_SetSystemEventState = e.newProc("SimConnect_SetSystemEventState")
+	// SimConnect_UnsubscribeFromSystemEvent procedure
+	SimConnect_UnsubscribeFromSystemEvent = e.newProc("SimConnect_UnsubscribeFromSystemEvent")
 	// SimConnect_EnumerateInputEventParams
-	SimConnect_EnumerateInputEvents = e.dll.NewProc("SimConnect_EnumerateInputEvents")
+	SimConnect_EnumerateInputEvents = e.newProc("SimConnect_EnumerateInputEvents")
 	// SimConnect_SubscribeInputEvent procedure
-	SimConnect_SubscribeInputEvent = e.dll.NewProc("SimConnect_SubscribeInputEvent")
+	SimConnect_SubscribeInputEvent = e.newProc("SimConnect_SubscribeInputEvent")
+	// SimConnect_UnsubscribeInputEvent procedure
+	SimConnect_UnsubscribeInputEvent = e.newProc("SimConnect_UnsubscribeInputEvent")
+	// SimConnect_GetInputEvent procedure
+	SimConnect_GetInputEvent = e.newProc("SimConnect_GetInputEvent")
+	// SimConnect_SetInputEvent procedure
+	SimConnect_SetInputEvent = e.newProc("SimConnect_SetInputEvent")
+	// SimConnect_EnumerateInputEventParams procedure
+	SimConnect_EnumerateInputEventParams = e.newProc("SimConnect_EnumerateInputEventParams")
 	// SimConnect_MapClientEventToSimEvent procedure
-	SimConnect_MapClientEventToSimEvent = e.dll.NewProc("SimConnect_MapClientEventToSimEvent")
+	SimConnect_MapClientEventToSimEvent = e.newProc("SimConnect_MapClientEventToSimEvent")
 	// SimConnect_TransmitClientEvent procedure
-	SimConnect_TransmitClientEvent = e.dll.NewProc("SimConnect_TransmitClientEvent")
+	SimConnect_TransmitClientEvent = e.newProc("SimConnect_TransmitClientEvent")
+	// SimConnect_TransmitClientEvent_EX1 procedure
+	SimConnect_TransmitClientEvent_EX1 = e.newProc("SimConnect_TransmitClientEvent_EX1")
 	// SimConnect_AddClientEventToNotificationGroup procedure
-	SimConnect_AddClientEventToNotificationGroup = e.dll.NewProc("SimConnect_AddClientEventToNotificationGroup")
+	SimConnect_AddClientEventToNotificationGroup = e.newProc("SimConnect_AddClientEventToNotificationGroup")
 	// SimConnect_SetNotificationGroupPriority procedure
-	SimConnect_SetNotificationGroupPriority = e.dll.NewProc("SimConnect_SetNotificationGroupPriority")
-	// Return nil to indicate that the procedures were loaded successfully, as there is no error handling on syscall.NewLazyProc.
+	SimConnect_SetNotificationGroupPriority = e.newProc("SimConnect_SetNotificationGroupPriority")
+	// SimConnect_RemoveClientEvent procedure
+	SimConnect_RemoveClientEvent = e.newProc("SimConnect_RemoveClientEvent")
+	// SimConnect_MapInputEventToClientEvent procedure
+	SimConnect_MapInputEventToClientEvent = e.newProc("SimConnect_MapInputEventToClientEvent")
+	// SimConnect_SetInputGroupPriority procedure
+	SimConnect_SetInputGroupPriority = e.newProc("SimConnect_SetInputGroupPriority")
+	// SimConnect_SetInputGroupState procedure
+	SimConnect_SetInputGroupState = e.newProc("SimConnect_SetInputGroupState")
+	// SimConnect_RemoveInputEvent procedure
+	SimConnect_RemoveInputEvent = e.newProc("SimConnect_RemoveInputEvent")
+	// SimConnect_ClearInputGroup procedure
+	SimConnect_ClearInputGroup = e.newProc("SimConnect_ClearInputGroup")
+	// SimConnect_EnumerateControllers procedure
+	SimConnect_EnumerateControllers = e.newProc("SimConnect_EnumerateControllers")
+	// SimConnect_MapClientDataNameToID procedure
+	SimConnect_MapClientDataNameToID = e.newProc("SimConnect_MapClientDataNameToID")
+	// SimConnect_CreateClientData procedure
+	SimConnect_CreateClientData = e.newProc("SimConnect_CreateClientData")
+	// SimConnect_AddToClientDataDefinition procedure
+	SimConnect_AddToClientDataDefinition = e.newProc("SimConnect_AddToClientDataDefinition")
+	// SimConnect_ClearClientDataDefinition procedure
+	SimConnect_ClearClientDataDefinition = e.newProc("SimConnect_ClearClientDataDefinition")
+	// SimConnect_RequestClientData procedure
+	SimConnect_RequestClientData = e.newProc("SimConnect_RequestClientData")
+	// SimConnect_SetClientData procedure
+	SimConnect_SetClientData = e.newProc("SimConnect_SetClientData")
+	// SimConnect_AddToFacilityDefinition procedure
+	SimConnect_AddToFacilityDefinition = e.newProc("SimConnect_AddToFacilityDefinition")
+	// SimConnect_RequestFacilityData procedure
+	SimConnect_RequestFacilityData = e.newProc("SimConnect_RequestFacilityData")
+	// SimConnect_RequestFacilitiesList procedure
+	SimConnect_RequestFacilitiesList = e.newProc("SimConnect_RequestFacilitiesList")
+	// SimConnect_RequestFacilitiesList_EX1 procedure
+	SimConnect_RequestFacilitiesList_EX1 = e.newProc("SimConnect_RequestFacilitiesList_EX1")
+	// SimConnect_SubscribeToFacilities procedure
+	SimConnect_SubscribeToFacilities = e.newProc("SimConnect_SubscribeToFacilities")
+	// SimConnect_SubscribeToFacilities_EX1 procedure
+	SimConnect_SubscribeToFacilities_EX1 = e.newProc("SimConnect_SubscribeToFacilities_EX1")
+	// SimConnect_UnsubscribeToFacilities procedure
+	SimConnect_UnsubscribeToFacilities = e.newProc("SimConnect_UnsubscribeToFacilities")
+	// SimConnect_UnsubscribeToFacilities_EX1 procedure
+	SimConnect_UnsubscribeToFacilities_EX1 = e.newProc("SimConnect_UnsubscribeToFacilities_EX1")
+	// SimConnect_FlightLoad procedure
+	SimConnect_FlightLoad = e.newProc("SimConnect_FlightLoad")
+	// SimConnect_FlightSave procedure
+	SimConnect_FlightSave = e.newProc("SimConnect_FlightSave")
+	// SimConnect_FlightPlanLoad procedure
+	SimConnect_FlightPlanLoad = e.newProc("SimConnect_FlightPlanLoad")
+	// SimConnect_ExecuteAction procedure
+	SimConnect_ExecuteAction = e.newProc("SimConnect_ExecuteAction")
+	// SimConnect_RequestJetwayData procedure
+	SimConnect_RequestJetwayData = e.newProc("SimConnect_RequestJetwayData")
+	// SimConnect_EnumerateSimObjectsAndLiveries procedure
+	SimConnect_EnumerateSimObjectsAndLiveries = e.newProc("SimConnect_EnumerateSimObjectsAndLiveries")
+	// SimConnect_SubscribeToFlowEvent procedure
+	SimConnect_SubscribeToFlowEvent = e.newProc("SimConnect_SubscribeToFlowEvent")
+	// SimConnect_UnsubscribeToFlowEvent procedure
+	SimConnect_UnsubscribeToFlowEvent = e.newProc("SimConnect_UnsubscribeToFlowEvent")
+	// SimConnect_GetLastSentPacketID procedure
+	SimConnect_GetLastSentPacketID = e.newProc("SimConnect_GetLastSentPacketID")
+	// Return nil to indicate that the procedures were declared; missing exports are found by probeCapabilities.
 	return nil
 }
@@ -1,8 +1,12 @@
 package client
 
 import (
+	"fmt"
+	"strings"
 	"unsafe"
 
+	"github.com/mycrew-online/sdk/internal/wire"
+	"github.com/mycrew-online/sdk/pkg/facilities"
 	"github.com/mycrew-online/sdk/pkg/types"
 )
 
@@ -314,7 +318,20 @@ func (e *Engine) parseSimConnectToChannelMessage(ppData uintptr, pcbData uint32)
 				Severity:      types.GetExceptionSeverity(exceptionCode),
 			}
 
+			// Trace the exception back to the call that sent the offending packet
+			e.annotateException(exceptionInfo)
+
 			msg["exception"] = exceptionInfo
+
+			// A failed flight plan load must not leave LoadFlightPlanAndWait hanging
+			if exceptionCode == types.SIMCONNECT_EXCEPTION_LOAD_FLIGHTPLAN_FAILED {
+				e.failFlightPlanLoad(exceptionInfo)
+			}
+			if exceptionCode == types.SIMCONNECT_EXCEPTION_JETWAY_DATA {
+				e.failJetwayRequest(exceptionInfo)
+			}
+			// An exception raised by an action fails the ExecuteAction call waiting for it
+			e.failAction(exceptionInfo)
 		}
 	}
 
@@ -322,6 +339,15 @@ func (e *Engine) parseSimConnectToChannelMessage(ppData uintptr, pcbData uint32)
 	if recv.DwID == types.SIMCONNECT_RECV_ID_EVENT {
 		if eventData := e.parseEventData(ppData, pcbData); eventData != nil {
 			msg["event"] = eventData
+			if action, intercepted, err := e.intercept(eventData); intercepted {
+				msg["intercepted"] = action.String()
+				if err != nil {
+					msg["intercept_error"] = err.Error()
+				}
+			}
+			if systemEvent := e.decodeSystemEvent(eventData.EventID, eventData.EventData, nil); systemEvent != nil {
+				msg["system_event"] = systemEvent
+			}
 		}
 	}
 
@@ -343,6 +369,7 @@ func (e *Engine) parseSimConnectToChannelMessage(ppData uintptr, pcbData uint32)
 	if recv.DwID == types.SIMCONNECT_RECV_ID_SYSTEM_STATE {
 		if stateData := e.parseSystemStateData(ppData, pcbData); stateData != nil {
 			msg["system_state"] = stateData
+			e.resolve(pendingKey{kind: "system_state", id: stateData.RequestID}, stateData)
 		}
 	}
 
@@ -350,6 +377,7 @@ func (e *Engine) parseSimConnectToChannelMessage(ppData uintptr, pcbData uint32)
 	if recv.DwID == types.SIMCONNECT_RECV_ID_CLIENT_DATA {
 		if clientData := e.parseClientData(ppData, pcbData); clientData != nil {
 			msg["client_data"] = clientData
+			e.resolve(pendingKey{kind: "client_data", id: clientData.RequestID}, clientData)
 		}
 	}
 	// For CUSTOM_ACTION, add the parsed custom action data
@@ -359,12 +387,23 @@ func (e *Engine) parseSimConnectToChannelMessage(ppData uintptr, pcbData uint32)
 		}
 	}
 
+	// For ACTION_CALLBACK, add the completed action and hand it to ExecuteAction
+	if recv.DwID == types.SIMCONNECT_RECV_ID_ACTION_CALLBACK {
+		if callback := e.parseActionCallback(ppData, pcbData); callback != nil {
+			msg["action_callback"] = callback
+			e.resolve(pendingKey{kind: "action", id: callback.RequestID}, callback)
+		}
+	}
+
 	// === NEW CRITICAL EVENT PARSERS ===
 
 	// For EVENT_OBJECT_ADDREMOVE, add the parsed object event data
 	if recv.DwID == types.SIMCONNECT_RECV_ID_EVENT_OBJECT_ADDREMOVE {
 		if objData := e.parseObjectAddRemoveData(ppData, pcbData); objData != nil {
 			msg["object_event"] = objData
+			if systemEvent := e.decodeSystemEvent(objData.EventID, objData.ObjectID, objData); systemEvent != nil {
+				msg["system_event"] = systemEvent
+			}
 		}
 	}
 
@@ -372,6 +411,10 @@ func (e *Engine) parseSimConnectToChannelMessage(ppData uintptr, pcbData uint32)
 	if recv.DwID == types.SIMCONNECT_RECV_ID_EVENT_FILENAME {
 		if filenameData := e.parseFilenameEventData(ppData, pcbData); filenameData != nil {
 			msg["filename_event"] = filenameData
+			if systemEvent := e.decodeSystemEvent(filenameData.EventID, 0, filenameData); systemEvent != nil {
+				msg["system_event"] = systemEvent
+			}
+			e.resolveFlightEvent(filenameData)
 		}
 	}
 
@@ -379,13 +422,140 @@ func (e *Engine) parseSimConnectToChannelMessage(ppData uintptr, pcbData uint32)
 	if recv.DwID == types.SIMCONNECT_RECV_ID_EVENT_FRAME {
 		if frameData := e.parseFrameEventData(ppData, pcbData); frameData != nil {
 			msg["frame_event"] = frameData
+			if systemEvent := e.decodeSystemEvent(frameData.EventID, 0, frameData); systemEvent != nil {
+				msg["system_event"] = systemEvent
+			}
 		}
 	}
 
-	// For FACILITY_DATA, add the parsed facility data
+	// For EVENT_MULTIPLAYER_* and EVENT_WEATHER_MODE, add the event header; the value is in DwData
+	switch recv.DwID {
+	case types.SIMCONNECT_RECV_ID_EVENT_MULTIPLAYER_SERVER_STARTED,
+		types.SIMCONNECT_RECV_ID_EVENT_MULTIPLAYER_CLIENT_STARTED,
+		types.SIMCONNECT_RECV_ID_EVENT_MULTIPLAYER_SESSION_ENDED,
+		types.SIMCONNECT_RECV_ID_EVENT_WEATHER_MODE:
+		if eventData := e.parseEventHeader(ppData, pcbData); eventData != nil {
+			msg["event"] = eventData
+			if systemEvent := e.decodeSystemEvent(eventData.EventID, eventData.EventData, nil); systemEvent != nil {
+				msg["system_event"] = systemEvent
+			}
+		}
+	}
+
+	// For EVENT_RACE_END and EVENT_RACE_LAP, add the parsed race result
+	if recv.DwID == types.SIMCONNECT_RECV_ID_EVENT_RACE_END || recv.DwID == types.SIMCONNECT_RECV_ID_EVENT_RACE_LAP {
+		if raceData := e.parseRaceEventData(ppData, pcbData); raceData != nil {
+			msg["race_event"] = raceData
+			if systemEvent := e.decodeSystemEvent(raceData.EventID, 0, raceData); systemEvent != nil {
+				msg["system_event"] = systemEvent
+			}
+		}
+	}
+
+	// For FACILITY_DATA, add the parsed facility data and the decoded record when the definition is known
 	if recv.DwID == types.SIMCONNECT_RECV_ID_FACILITY_DATA {
 		if facilityData := e.parseFacilityData(ppData, pcbData); facilityData != nil {
 			msg["facility_data"] = facilityData
+			if node, err := e.collectFacilityRecord(facilityData); err != nil {
+				msg["facility_error"] = err
+			} else if node != nil {
+				msg["facility_node"] = node
+			}
+		}
+	}
+
+	// For FACILITY_DATA_END, add the completed facility tree
+	if recv.DwID == types.SIMCONNECT_RECV_ID_FACILITY_DATA_END {
+		if endData := e.parseFacilityDataEnd(ppData, pcbData); endData != nil {
+			msg["facility_data_end"] = endData
+			if result := e.completeFacilityRequest(endData.RequestID); result != nil {
+				msg["facility"] = result
+			}
+		}
+	}
+
+	// For facility lists, add the parsed page and the aggregated list once the last page arrives
+	switch recv.DwID {
+	case types.SIMCONNECT_RECV_ID_AIRPORT_LIST,
+		types.SIMCONNECT_RECV_ID_VOR_LIST,
+		types.SIMCONNECT_RECV_ID_NDB_LIST,
+		types.SIMCONNECT_RECV_ID_WAYPOINT_LIST,
+		types.SIMCONNECT_RECV_ID_FACILITY_MINIMAL_LIST:
+		if page := e.parseFacilityList(ppData, pcbData); page != nil {
+			msg["facility_list"] = page
+			if complete := e.collectFacilityListPage(page); complete != nil {
+				msg["facility_list_complete"] = complete
+			}
+		}
+	}
+
+	// For CONTROLLERS_LIST, add the parsed page and the aggregated list once the last page arrives
+	if recv.DwID == types.SIMCONNECT_RECV_ID_CONTROLLERS_LIST {
+		if page := e.parseControllersList(ppData, pcbData); page != nil {
+			msg["controllers_list"] = page
+			if complete := e.collectControllerPage(page); complete != nil {
+				msg["controllers_list_complete"] = complete
+			}
+		}
+	}
+
+	// For JETWAY_DATA, add the parsed page and the aggregated list once the last page arrives
+	if recv.DwID == types.SIMCONNECT_RECV_ID_JETWAY_DATA {
+		if page := e.parseJetwayData(ppData, pcbData); page != nil {
+			msg["jetway_data"] = page
+			if complete := e.collectJetwayPage(page); complete != nil {
+				msg["jetway_data_complete"] = complete
+			}
+		}
+	}
+
+	// For ENUMERATE_INPUT_EVENTS, add the parsed page and the aggregated list once the last page arrives
+	if recv.DwID == types.SIMCONNECT_RECV_ID_ENUMERATE_INPUT_EVENTS {
+		if page := e.parseInputEventList(ppData, pcbData); page != nil {
+			msg["input_event_list"] = page
+			if complete := e.collectInputEventPage(page); complete != nil {
+				msg["input_event_list_complete"] = complete
+			}
+		}
+	}
+
+	// For GET_INPUT_EVENT, add the value and hand it to a waiting GetInputEvent
+	if recv.DwID == types.SIMCONNECT_RECV_ID_GET_INPUT_EVENT {
+		if value := e.parseGetInputEvent(ppData, pcbData); value != nil {
+			msg["input_event"] = value
+			e.resolve(pendingKey{kind: "input_event", id: value.RequestID}, value)
+		}
+	}
+
+	// For SUBSCRIBE_INPUT_EVENT, add the changed value
+	if recv.DwID == types.SIMCONNECT_RECV_ID_SUBSCRIBE_INPUT_EVENT {
+		if value := e.parseSubscribeInputEvent(ppData, pcbData); value != nil {
+			msg["input_event"] = value
+		}
+	}
+
+	// For ENUMERATE_INPUT_EVENT_PARAMS, add the parameter list
+	if recv.DwID == types.SIMCONNECT_RECV_ID_ENUMERATE_INPUT_EVENT_PARAMS {
+		if params := e.parseInputEventParams(ppData, pcbData); params != nil {
+			msg["input_event_params"] = params
+			e.resolve(pendingKey{kind: "input_event_params", id: foldHash(params.Hash)}, params)
+		}
+	}
+
+	// For ENUMERATE_SIMOBJECT_AND_LIVERY_LIST, add the parsed page and the aggregated list once the last page arrives
+	if recv.DwID == types.SIMCONNECT_RECV_ID_ENUMERATE_SIMOBJECT_AND_LIVERY_LIST {
+		if page := e.parseSimObjectLiveryList(ppData, pcbData); page != nil {
+			msg["simobject_livery_list"] = page
+			if complete := e.collectLiveryPage(page); complete != nil {
+				msg["simobject_livery_list_complete"] = complete
+			}
+		}
+	}
+
+	// For FLOW_EVENT, add the parsed flow event
+	if recv.DwID == types.SIMCONNECT_RECV_ID_FLOW_EVENT {
+		if flowEvent := e.parseFlowEvent(ppData, pcbData); flowEvent != nil {
+			msg["flow_event"] = flowEvent
 		}
 	}
 
@@ -496,6 +666,10 @@ func getMessageTypeName(id types.SimConnectRecvID) string {
 		return "SUBSCRIBE_INPUT_EVENT"
 	case types.SIMCONNECT_RECV_ID_ENUMERATE_INPUT_EVENT_PARAMS:
 		return "ENUMERATE_INPUT_EVENT_PARAMS"
+	case types.SIMCONNECT_RECV_ID_ENUMERATE_SIMOBJECT_AND_LIVERY_LIST:
+		return "ENUMERATE_SIMOBJECT_AND_LIVERY_LIST"
+	case types.SIMCONNECT_RECV_ID_FLOW_EVENT:
+		return "FLOW_EVENT"
 	default:
 		return "UNKNOWN"
 	}
@@ -521,11 +695,78 @@ func (e *Engine) parseEventData(ppData uintptr, pcbData uint32) *types.EventData
 	}
 
 	// Classify event type and resolve event name based on EventID
-	//result.EventType, result.EventName = classifyEvent(eventData.UEventID, eventData.UGroupID)
+	result.Kind, result.EventName, result.GroupName = e.classifyEvent(eventData.UEventID, eventData.UGroupID)
 
 	return result
 }
 
+// parseEventHeader extracts the SIMCONNECT_RECV_EVENT header of messages that add no fields to it:
+// EVENT_MULTIPLAYER_SERVER_STARTED, EVENT_MULTIPLAYER_CLIENT_STARTED, EVENT_MULTIPLAYER_SESSION_ENDED
+// and EVENT_WEATHER_MODE
+func (e *Engine) parseEventHeader(ppData uintptr, pcbData uint32) *types.EventData {
+	if ppData == 0 || pcbData < uint32(unsafe.Sizeof(types.SIMCONNECT_RECV_EVENT{})) {
+		return nil
+	}
+
+	eventData := (*types.SIMCONNECT_RECV_EVENT)(unsafe.Pointer(ppData))
+	result := &types.EventData{
+		GroupID:   eventData.UGroupID,
+		EventID:   eventData.UEventID,
+		EventData: eventData.DwData,
+		EventType: eventData.DwID,
+	}
+	result.Kind, result.EventName, result.GroupName = e.classifyEvent(eventData.UEventID, eventData.UGroupID)
+
+	return result
+}
+
+// parseRaceEventData extracts SIMCONNECT_RECV_EVENT_RACE_END and SIMCONNECT_RECV_EVENT_RACE_LAP:
+// the event header, DWORD dwRacerNumber or dwLapIndex, then SIMCONNECT_DATA_RACE_RESULT
+func (e *Engine) parseRaceEventData(ppData uintptr, pcbData uint32) *types.RaceEventData {
+	payload := copyPayload(ppData, pcbData, unsafe.Sizeof(types.SIMCONNECT_RECV{}))
+	if payload == nil {
+		return nil
+	}
+	recv := (*types.SIMCONNECT_RECV)(unsafe.Pointer(ppData))
+
+	r := wire.NewReader(payload)
+	race := &types.RaceEventData{
+		GroupID:   r.Uint32(),
+		EventID:   r.Uint32(),
+		EventType: recv.DwID,
+	}
+	r.Skip(4) // DwData
+	if recv.DwID == types.SIMCONNECT_RECV_ID_EVENT_RACE_LAP {
+		race.LapIndex = r.Uint32()
+	} else {
+		race.RacerNumber = r.Uint32()
+	}
+	race.Result = types.RaceResult{
+		NumberOfRacers: r.Uint32(),
+		MissionGUID:    formatGUID(r.Bytes(16)),
+		PlayerName:     r.String(260),
+		SessionType:    r.String(260),
+		Aircraft:       r.String(260),
+		PlayerRole:     r.String(260),
+		TotalTime:      r.Float64(),
+		PenaltyTime:    r.Float64(),
+		IsDisqualified: r.Uint32() != 0,
+	}
+	if r.Err() {
+		return nil
+	}
+	return race
+}
+
+// formatGUID formats a Windows GUID (DWORD, WORD, WORD, BYTE[8]) as {XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX}
+func formatGUID(b []byte) string {
+	if len(b) != 16 {
+		return ""
+	}
+	r := wire.NewReader(b)
+	return fmt.Sprintf("{%08X-%04X-%04X-%X-%X}", r.Uint32(), r.Uint16(), r.Uint16(), b[8:10], b[10:16])
+}
+
 // parseEventExData extracts extended event data from SIMCONNECT_RECV_EVENT_EX1 message
 func (e *Engine) parseEventExData(ppData uintptr, pcbData uint32) *types.EventExData {
 	if ppData == 0 || pcbData == 0 {
@@ -550,6 +791,7 @@ func (e *Engine) parseEventExData(ppData uintptr, pcbData uint32) *types.EventEx
 			eventData.DwData4,
 		},
 	}
+	result.Kind, result.EventName, result.GroupName = e.classifyEvent(eventData.UEventID, eventData.UGroupID)
 
 	return result
 }
@@ -611,8 +853,9 @@ func (e *Engine) parseSystemStateData(ppData uintptr, pcbData uint32) *types.Sys
 }
 
 // parseClientData extracts client data from SIMCONNECT_RECV_CLIENT_DATA message
+// Entries are decoded into Values when the definition was registered with RegisterClientDataDefinition
 func (e *Engine) parseClientData(ppData uintptr, pcbData uint32) *types.ClientData {
-	if ppData == 0 || pcbData == 0 {
+	if ppData == 0 || pcbData < uint32(unsafe.Offsetof(types.SIMCONNECT_RECV_CLIENT_DATA{}.DwData)) {
 		return nil
 	}
 
@@ -622,32 +865,35 @@ func (e *Engine) parseClientData(ppData uintptr, pcbData uint32) *types.ClientDa
 		return nil
 	}
 
-	// For client data, we need to parse the actual data based on the definition
-	// For now, we'll store the raw data pointer and size
-	var data interface{}
-	headerSize := unsafe.Sizeof(*clientData)
-	if pcbData > uint32(headerSize) {
-		// Calculate data location and available bytes
-		dataPtr := ppData + uintptr(headerSize)
-		dataLen := pcbData - uint32(headerSize)
-
-		// For basic implementation, store as byte slice
-		dataBytes := make([]byte, dataLen)
-		for i := uint32(0); i < dataLen; i++ {
-			dataBytes[i] = *(*byte)(unsafe.Pointer(dataPtr + uintptr(i)))
-		}
-		data = dataBytes
+	// The data block starts at DwData
+	data := copyPayload(ppData, pcbData, unsafe.Offsetof(clientData.DwData))
+	if data == nil {
+		data = []byte{}
 	}
 
 	// Create client data structure for channel message
 	result := &types.ClientData{
 		RequestID:    clientData.DwRequestID,
 		DefineID:     clientData.DwDefineID,
+		Flags:        clientData.DwFlags,
 		EntryNumber:  clientData.DwEntryNumber,
 		TotalEntries: clientData.DwOutOf,
 		Data:         data,
 	}
 
+	// Decode the entries when the layout is known (thread-safe)
+	e.mu.RLock()
+	def, exists := e.clientDataDefinitions[clientData.DwDefineID]
+	e.mu.RUnlock()
+
+	if exists {
+		if clientData.DwFlags&types.SIMCONNECT_CLIENT_DATA_REQUEST_FLAG_TAGGED != 0 {
+			result.Values, _ = def.DecodeTagged(data)
+		} else {
+			result.Values, _ = def.Decode(data)
+		}
+	}
+
 	return result
 }
 
@@ -673,6 +919,29 @@ func (e *Engine) parseCustomActionData(ppData uintptr, pcbData uint32) *types.Cu
 	return result
 }
 
+// parseActionCallback extracts the completed action from SIMCONNECT_RECV_ACTION_CALLBACK message
+func (e *Engine) parseActionCallback(ppData uintptr, pcbData uint32) *types.ActionCallback {
+	if ppData == 0 || pcbData < uint32(unsafe.Sizeof(types.SIMCONNECT_RECV_ACTION_CALLBACK{})) {
+		return nil
+	}
+
+	callback := (*types.SIMCONNECT_RECV_ACTION_CALLBACK)(unsafe.Pointer(ppData))
+
+	// Extract the null-terminated action ID
+	actionID := ""
+	for i, b := range callback.SzActionID {
+		if b == 0 {
+			actionID = string(callback.SzActionID[:i])
+			break
+		}
+	}
+
+	return &types.ActionCallback{
+		RequestID: callback.DwRequestID,
+		ActionID:  actionID,
+	}
+}
+
 // === NEW CRITICAL EVENT PARSERS ===
 
 // parseObjectAddRemoveData extracts object add/remove event data from SIMCONNECT_RECV_EVENT_OBJECT_ADDREMOVE message
@@ -687,16 +956,21 @@ func (e *Engine) parseObjectAddRemoveData(ppData uintptr, pcbData uint32) *types
 		return nil
 	}
 
-	// Determine action based on event ID (this is application-specific)
+	// Determine action from the system event the ID was subscribed to
 	action := "unknown"
-	// Note: In practice, you would map specific event IDs to "added" or "removed"
-	// This requires knowledge of your registered event IDs
+	switch e.systemEventName(objEvent.UEventID) {
+	case types.SIMCONNECT_SYSTEM_EVENT_OBJECT_ADDED:
+		action = "added"
+	case types.SIMCONNECT_SYSTEM_EVENT_OBJECT_REMOVED:
+		action = "removed"
+	}
 
 	// Create object add/remove data structure for channel message
 	result := &types.ObjectAddRemoveData{
-		EventID:  objEvent.UEventID,
-		ObjectID: objEvent.DwData,
-		Action:   action,
+		EventID:    objEvent.UEventID,
+		ObjectID:   objEvent.DwData,
+		ObjectType: objEvent.EObjType,
+		Action:     action,
 	}
 
 	return result
@@ -727,7 +1001,7 @@ func (e *Engine) parseFilenameEventData(ppData uintptr, pcbData uint32) *types.F
 	result := &types.FilenameEventData{
 		EventID:  filenameEvent.UEventID,
 		Flags:    filenameEvent.DwFlags,
-		GroupID:  filenameEvent.DwGroupID,
+		GroupID:  filenameEvent.UGroupID,
 		Filename: filename,
 	}
 
@@ -748,8 +1022,9 @@ func (e *Engine) parseFrameEventData(ppData uintptr, pcbData uint32) *types.Fram
 
 	// Create frame event data structure for channel message
 	result := &types.FrameEventData{
-		FrameRate: frameEvent.DwFrameRate,
-		SimSpeed:  frameEvent.DwSimSpeed,
+		EventID:   frameEvent.UEventID,
+		FrameRate: frameEvent.FFrameRate,
+		SimSpeed:  frameEvent.FSimSpeed,
 	}
 
 	return result
@@ -767,34 +1042,272 @@ func (e *Engine) parseFacilityData(ppData uintptr, pcbData uint32) *types.Facili
 		return nil
 	}
 
-	// The actual facility data follows the header
-	headerSize := unsafe.Sizeof(*facilityData)
-	var data interface{}
-
-	if pcbData > uint32(headerSize) {
-		// Extract raw data bytes for now
-		// In practice, this would be parsed based on the specific facility type
-		dataLen := pcbData - uint32(headerSize)
-		dataPtr := ppData + uintptr(headerSize)
-		dataBytes := make([]byte, dataLen)
-		for i := uint32(0); i < dataLen; i++ {
-			dataBytes[i] = *(*byte)(unsafe.Pointer(dataPtr + uintptr(i)))
-		}
-		data = dataBytes
-	}
+	// The field values start at the Data member; their layout depends on the facility definition
+	dataOffset := unsafe.Offsetof(facilityData.Data)
 
 	// Create facility data structure for channel message
 	result := &types.FacilityData{
-		RequestID:    facilityData.DwRequestID,
-		ArraySize:    facilityData.DwArraySize,
-		EntryNumber:  facilityData.DwEntryNumber,
-		TotalEntries: facilityData.DwOutOf,
-		Data:         data,
+		RequestID:       facilityData.UserRequestId,
+		UniqueRequestID: facilityData.UniqueRequestId,
+		ParentID:        facilityData.ParentUniqueRequestId,
+		Type:            facilityData.Type,
+		IsListItem:      facilityData.IsListItem != 0,
+		ItemIndex:       facilityData.ItemIndex,
+		ListSize:        facilityData.ListSize,
+		Data:            copyPayload(ppData, pcbData, dataOffset),
 	}
 
 	return result
 }
 
+// parseFacilityDataEnd extracts the request ID from SIMCONNECT_RECV_FACILITY_DATA_END message
+func (e *Engine) parseFacilityDataEnd(ppData uintptr, pcbData uint32) *types.FacilityDataEnd {
+	if ppData == 0 || pcbData < uint32(unsafe.Sizeof(types.SIMCONNECT_RECV_FACILITY_DATA_END{})) {
+		return nil
+	}
+
+	endData := (*types.SIMCONNECT_RECV_FACILITY_DATA_END)(unsafe.Pointer(ppData))
+	if endData.DwID != types.SIMCONNECT_RECV_ID_FACILITY_DATA_END {
+		return nil
+	}
+
+	return &types.FacilityDataEnd{
+		RequestID: endData.RequestId,
+	}
+}
+
+// parseFacilityList extracts one page of an airport, VOR, NDB, waypoint or minimal facility list
+func (e *Engine) parseFacilityList(ppData uintptr, pcbData uint32) *types.FacilityListData {
+	if ppData == 0 || pcbData < uint32(unsafe.Sizeof(types.SIMCONNECT_RECV_LIST_TEMPLATE{})) {
+		return nil
+	}
+
+	// All facility lists share the SIMCONNECT_RECV_LIST_TEMPLATE header
+	list := (*types.SIMCONNECT_RECV_LIST_TEMPLATE)(unsafe.Pointer(ppData))
+
+	// Minimal lists do not say which type they contain, so use the type recorded with the request
+	e.mu.RLock()
+	listType := e.facilityListTypes[list.DwRequestID]
+	e.mu.RUnlock()
+
+	header := types.FacilityListData{
+		RequestID:   list.DwRequestID,
+		ListType:    listType,
+		EntryNumber: list.DwEntryNumber,
+		OutOf:       list.DwOutOf,
+	}
+	payload := copyPayload(ppData, pcbData, unsafe.Sizeof(*list))
+
+	return facilities.DecodeList(list.DwID, header, list.DwArraySize, payload)
+}
+
+// Controller item layout: char DeviceName[256], DeviceId, ProductId, CompositeID, SIMCONNECT_VERSION_BASE_TYPE (packed)
+const controllerItemSize = 256 + 4 + 4 + 4 + 8
+
+// parseControllersList extracts a page of SIMCONNECT_RECV_CONTROLLERS_LIST
+func (e *Engine) parseControllersList(ppData uintptr, pcbData uint32) *types.ControllerList {
+	header, controllers, ok := decodeListPage(ppData, pcbData, controllerItemSize, func(r *wire.Reader) types.ControllerItem {
+		return types.ControllerItem{
+			DeviceName:  r.String(256),
+			DeviceID:    r.Uint32(),
+			ProductID:   r.Uint32(),
+			CompositeID: r.Uint32(),
+			HardwareVersion: types.VersionBase{
+				Major:    r.Uint16(),
+				Minor:    r.Uint16(),
+				Revision: r.Uint16(),
+				Build:    r.Uint16(),
+			},
+		}
+	})
+	if !ok {
+		return nil
+	}
+	return &types.ControllerList{
+		RequestID:   header.requestID,
+		EntryNumber: header.entryNumber,
+		OutOf:       header.outOf,
+		Controllers: controllers,
+	}
+}
+
+// Jetway data layout: char AirportIcao[8], int ParkingIndex, SIMCONNECT_DATA_LATLONALT, SIMCONNECT_DATA_PBH,
+// int Status, int Door, four SIMCONNECT_DATA_XYZ, JetwayObjectId, AttachedObjectId (packed)
+const jetwayDataSize = 8 + 4 + 24 + 12 + 4 + 4 + 4*24 + 4 + 4
+
+// parseJetwayData extracts a page of SIMCONNECT_RECV_JETWAY_DATA
+func (e *Engine) parseJetwayData(ppData uintptr, pcbData uint32) *types.JetwayList {
+	readXYZ := func(r *wire.Reader) types.XYZ {
+		return types.XYZ{X: r.Float64(), Y: r.Float64(), Z: r.Float64()}
+	}
+	header, jetways, ok := decodeListPage(ppData, pcbData, jetwayDataSize, func(r *wire.Reader) types.JetwayData {
+		return types.JetwayData{
+			AirportICAO:  r.String(8),
+			ParkingIndex: r.Int32(),
+			Position: types.LatLonAlt{
+				Latitude:  r.Float64(),
+				Longitude: r.Float64(),
+				Altitude:  r.Float64(),
+			},
+			Orientation: types.PBH{
+				Pitch:   r.Float32(),
+				Bank:    r.Float32(),
+				Heading: r.Float32(),
+			},
+			Status:              types.JetwayStatus(r.Int32()),
+			Door:                r.Int32(),
+			ExitDoorRelativePos: readXYZ(r),
+			MainHandlePos:       readXYZ(r),
+			SecondaryHandlePos:  readXYZ(r),
+			WheelGroundLockPos:  readXYZ(r),
+			JetwayObjectID:      r.Uint32(),
+			AttachedObjectID:    r.Uint32(),
+		}
+	})
+	if !ok {
+		return nil
+	}
+	return &types.JetwayList{
+		RequestID:   header.requestID,
+		EntryNumber: header.entryNumber,
+		OutOf:       header.outOf,
+		Jetways:     jetways,
+	}
+}
+
+// SimObject and livery item layout: char AircraftTitle[256], char LiveryName[256]
+const simObjectLiverySize = 256 + 256
+
+// parseSimObjectLiveryList extracts a page of SIMCONNECT_RECV_ENUMERATE_SIMOBJECT_AND_LIVERY_LIST
+func (e *Engine) parseSimObjectLiveryList(ppData uintptr, pcbData uint32) *types.SimObjectLiveryList {
+	header, liveries, ok := decodeListPage(ppData, pcbData, simObjectLiverySize, func(r *wire.Reader) types.SimObjectLivery {
+		return types.SimObjectLivery{
+			Title:  r.String(256),
+			Livery: r.String(256),
+		}
+	})
+	if !ok {
+		return nil
+	}
+	return &types.SimObjectLiveryList{
+		RequestID:   header.requestID,
+		EntryNumber: header.entryNumber,
+		OutOf:       header.outOf,
+		Liveries:    liveries,
+	}
+}
+
+// parseFlowEvent extracts the flow event ID and flight file from SIMCONNECT_RECV_FLOW_EVENT message
+func (e *Engine) parseFlowEvent(ppData uintptr, pcbData uint32) *types.FlowEvent {
+	if ppData == 0 || pcbData < uint32(unsafe.Offsetof(types.SIMCONNECT_RECV_FLOW_EVENT{}.SzFltPath)) {
+		return nil
+	}
+
+	flowEvent := (*types.SIMCONNECT_RECV_FLOW_EVENT)(unsafe.Pointer(ppData))
+	eventID := types.FlowEventID(flowEvent.FlowEvent)
+
+	return &types.FlowEvent{
+		EventID: eventID,
+		Name:    eventID.String(),
+		FltPath: e.parseFixedString(ppData, pcbData, unsafe.Offsetof(flowEvent.SzFltPath), len(flowEvent.SzFltPath)),
+	}
+}
+
+// Input event descriptor layout: char Name[64], UINT64 Hash, SIMCONNECT_DATATYPE eType (packed)
+const inputEventDescriptorSize = 64 + 8 + 4
+
+// parseInputEventList extracts a page of SIMCONNECT_RECV_ENUMERATE_INPUT_EVENTS
+func (e *Engine) parseInputEventList(ppData uintptr, pcbData uint32) *types.InputEventList {
+	header, events, ok := decodeListPage(ppData, pcbData, inputEventDescriptorSize, func(r *wire.Reader) types.InputEventDescriptor {
+		return types.InputEventDescriptor{
+			Name: r.String(64),
+			Hash: r.Uint64(),
+			Type: types.SimConnectDataType(r.Uint32()),
+		}
+	})
+	if !ok {
+		return nil
+	}
+	return &types.InputEventList{
+		RequestID:   header.requestID,
+		EntryNumber: header.entryNumber,
+		OutOf:       header.outOf,
+		Events:      events,
+	}
+}
+
+// parseGetInputEvent extracts SIMCONNECT_RECV_GET_INPUT_EVENT: DWORD RequestID, eType, then the value
+func (e *Engine) parseGetInputEvent(ppData uintptr, pcbData uint32) *types.InputEventValue {
+	payload := copyPayload(ppData, pcbData, unsafe.Sizeof(types.SIMCONNECT_RECV{}))
+	if len(payload) < 8 {
+		return nil
+	}
+
+	r := wire.NewReader(payload)
+	value := &types.InputEventValue{
+		RequestID: r.Uint32(),
+		Type:      types.InputEventType(r.Uint32()),
+	}
+	readInputEventValue(inputEventValueBytes(ppData, pcbData, unsafe.Sizeof(types.SIMCONNECT_RECV{})+8), value)
+	return value
+}
+
+// parseSubscribeInputEvent extracts SIMCONNECT_RECV_SUBSCRIBE_INPUT_EVENT: UINT64 Hash, eType, then the value
+func (e *Engine) parseSubscribeInputEvent(ppData uintptr, pcbData uint32) *types.InputEventValue {
+	payload := copyPayload(ppData, pcbData, unsafe.Sizeof(types.SIMCONNECT_RECV{}))
+	if len(payload) < 12 {
+		return nil
+	}
+
+	r := wire.NewReader(payload)
+	value := &types.InputEventValue{
+		Hash: r.Uint64(),
+		Type: types.InputEventType(r.Uint32()),
+	}
+	value.Name = e.inputEventName(value.Hash)
+	readInputEventValue(inputEventValueBytes(ppData, pcbData, unsafe.Sizeof(types.SIMCONNECT_RECV{})+12), value)
+	return value
+}
+
+// parseInputEventParams extracts SIMCONNECT_RECV_ENUMERATE_INPUT_EVENT_PARAMS: UINT64 Hash, char Value[MAX_PATH]
+func (e *Engine) parseInputEventParams(ppData uintptr, pcbData uint32) *types.InputEventParams {
+	payload := copyPayload(ppData, pcbData, unsafe.Sizeof(types.SIMCONNECT_RECV{}))
+	if len(payload) < 8 {
+		return nil
+	}
+
+	r := wire.NewReader(payload)
+	params := &types.InputEventParams{Hash: r.Uint64()}
+	params.Raw = wire.CString(r.Rest())
+	for _, p := range strings.Split(params.Raw, ";") {
+		if p = strings.TrimSpace(p); p != "" {
+			params.Params = append(params.Params, p)
+		}
+	}
+	return params
+}
+
+// inputEventValueBytes returns the data of the Value field at offset. Value is declared as void*:
+// when it holds an address inside the message the data is read from there, otherwise it is inline.
+func inputEventValueBytes(ppData uintptr, pcbData uint32, offset uintptr) []byte {
+	if uint32(offset)+uint32(unsafe.Sizeof(uintptr(0))) <= pcbData {
+		ptr := *(*uintptr)(unsafe.Pointer(ppData + offset))
+		if ptr > ppData && ptr < ppData+uintptr(pcbData) {
+			return copyPayload(ppData, pcbData, ptr-ppData)
+		}
+	}
+	return copyPayload(ppData, pcbData, offset)
+}
+
+// readInputEventValue decodes the double or string value of an input event message
+func readInputEventValue(data []byte, value *types.InputEventValue) {
+	if value.Type == types.SIMCONNECT_INPUT_EVENT_TYPE_STRING {
+		value.String = wire.CString(data)
+		return
+	}
+	value.Float = wire.NewReader(data).Float64()
+}
+
 // parsePickEventData extracts pick event data from SIMCONNECT_RECV_PICK message
 func (e *Engine) parsePickEventData(ppData uintptr, pcbData uint32) *types.PickEventData {
 	if ppData == 0 || pcbData == 0 {
@@ -831,11 +1344,32 @@ func (e *Engine) isHandledMessageType(messageType types.SimConnectRecvID) bool {
 		types.SIMCONNECT_RECV_ID_SYSTEM_STATE,
 		types.SIMCONNECT_RECV_ID_CLIENT_DATA,
 		types.SIMCONNECT_RECV_ID_CUSTOM_ACTION,
+		types.SIMCONNECT_RECV_ID_ACTION_CALLBACK,
 		// New critical parsers
 		types.SIMCONNECT_RECV_ID_EVENT_OBJECT_ADDREMOVE,
 		types.SIMCONNECT_RECV_ID_EVENT_FILENAME,
 		types.SIMCONNECT_RECV_ID_EVENT_FRAME,
+		types.SIMCONNECT_RECV_ID_EVENT_MULTIPLAYER_SERVER_STARTED,
+		types.SIMCONNECT_RECV_ID_EVENT_MULTIPLAYER_CLIENT_STARTED,
+		types.SIMCONNECT_RECV_ID_EVENT_MULTIPLAYER_SESSION_ENDED,
+		types.SIMCONNECT_RECV_ID_EVENT_WEATHER_MODE,
+		types.SIMCONNECT_RECV_ID_EVENT_RACE_END,
+		types.SIMCONNECT_RECV_ID_EVENT_RACE_LAP,
 		types.SIMCONNECT_RECV_ID_FACILITY_DATA,
+		types.SIMCONNECT_RECV_ID_FACILITY_DATA_END,
+		types.SIMCONNECT_RECV_ID_AIRPORT_LIST,
+		types.SIMCONNECT_RECV_ID_VOR_LIST,
+		types.SIMCONNECT_RECV_ID_NDB_LIST,
+		types.SIMCONNECT_RECV_ID_WAYPOINT_LIST,
+		types.SIMCONNECT_RECV_ID_FACILITY_MINIMAL_LIST,
+		types.SIMCONNECT_RECV_ID_CONTROLLERS_LIST,
+		types.SIMCONNECT_RECV_ID_JETWAY_DATA,
+		types.SIMCONNECT_RECV_ID_ENUMERATE_INPUT_EVENTS,
+		types.SIMCONNECT_RECV_ID_GET_INPUT_EVENT,
+		types.SIMCONNECT_RECV_ID_SUBSCRIBE_INPUT_EVENT,
+		types.SIMCONNECT_RECV_ID_ENUMERATE_INPUT_EVENT_PARAMS,
+		types.SIMCONNECT_RECV_ID_ENUMERATE_SIMOBJECT_AND_LIVERY_LIST,
+		types.SIMCONNECT_RECV_ID_FLOW_EVENT,
 		types.SIMCONNECT_RECV_ID_PICK:
 		return true
 	default:
@@ -898,6 +1432,19 @@ func (e *Engine) shouldLogUnhandledMessage(messageType types.SimConnectRecvID) b
 	}
 }
 
+// copyPayload copies the bytes of a message from offset to its end.
+// The dispatch buffer is reused by SimConnect, so payloads kept beyond the
+// current message must be copied out.
+func copyPayload(ppData uintptr, pcbData uint32, offset uintptr) []byte {
+	if ppData == 0 || uint32(offset) >= pcbData {
+		return nil
+	}
+	size := int(pcbData - uint32(offset))
+	payload := make([]byte, size)
+	copy(payload, unsafe.Slice((*byte)(unsafe.Pointer(ppData+offset)), size))
+	return payload
+}
+
 // Helper functions for parsing different SimConnect data types
 
 // parseVariableString parses SIMCONNECT_DATATYPE_STRINGV - variable length string
@@ -1,15 +1,58 @@
 package client
 
-// TODO HRESULT constants add more???
+import "fmt"
+
+// HRESULT constants returned by SimConnect calls
 const (
-	S_OK         = uint32(0x00000000)
-	E_FAIL       = uint32(0x80004005)
-	E_INVALIDARG = uint32(0x80070057)
+	S_OK           = uint32(0x00000000)
+	S_FALSE        = uint32(0x00000001)
+	E_NOTIMPL      = uint32(0x80004001)
+	E_NOINTERFACE  = uint32(0x80004002)
+	E_POINTER      = uint32(0x80004003)
+	E_ABORT        = uint32(0x80004004)
+	E_FAIL         = uint32(0x80004005)
+	E_UNEXPECTED   = uint32(0x8000FFFF)
+	E_ACCESSDENIED = uint32(0x80070005)
+	E_HANDLE       = uint32(0x80070006)
+	E_OUTOFMEMORY  = uint32(0x8007000E)
+	E_INVALIDARG   = uint32(0x80070057)
 )
 
+// hresultNames decodes the known HRESULT codes
+var hresultNames = map[uint32]struct{ name, description string }{
+	S_OK:           {"S_OK", "success"},
+	S_FALSE:        {"S_FALSE", "success, with a false result"},
+	E_NOTIMPL:      {"E_NOTIMPL", "not implemented, e.g. an export missing from the SimConnect DLL"},
+	E_NOINTERFACE:  {"E_NOINTERFACE", "interface not supported"},
+	E_POINTER:      {"E_POINTER", "invalid pointer"},
+	E_ABORT:        {"E_ABORT", "operation aborted"},
+	E_FAIL:         {"E_FAIL", "unspecified failure, e.g. no simulator to connect to"},
+	E_UNEXPECTED:   {"E_UNEXPECTED", "unexpected failure"},
+	E_ACCESSDENIED: {"E_ACCESSDENIED", "access denied"},
+	E_HANDLE:       {"E_HANDLE", "invalid handle"},
+	E_OUTOFMEMORY:  {"E_OUTOFMEMORY", "out of memory"},
+	E_INVALIDARG:   {"E_INVALIDARG", "invalid argument"},
+}
+
 func IsHRESULTSuccess(hresult uint32) bool {
 	return hresult == S_OK
 }
 func IsHRESULTFailure(hresult uint32) bool {
 	return hresult != S_OK
 }
+
+// HRESULTName returns the name of an HRESULT code, e.g. "E_FAIL", or its hex value when unknown
+func HRESULTName(hresult uint32) string {
+	if known, exists := hresultNames[hresult]; exists {
+		return known.name
+	}
+	return fmt.Sprintf("0x%08X", hresult)
+}
+
+// HRESULTDescription returns a short description of an HRESULT code
+func HRESULTDescription(hresult uint32) string {
+	if known, exists := hresultNames[hresult]; exists {
+		return known.description
+	}
+	return "unknown HRESULT"
+}
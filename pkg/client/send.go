@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// maxEventParams is the number of data parameters SimConnect_TransmitClientEvent_EX1 carries
+const maxEventParams = 5
+
+// TransmitClientEvent_EX1 transmits a client event with up to five data parameters
+func (e *Engine) TransmitClientEvent_EX1(objectID uint32, eventID types.ClientEventID, groupID types.NotificationGroupID, flags uint32, data ...uint32) error {
+	if len(data) > maxEventParams {
+		return fmt.Errorf("too many event parameters: %d (max %d)", len(data), maxEventParams)
+	}
+
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	var params [maxEventParams]uint32
+	copy(params[:], data)
+
+	// Get handle (thread-safe)
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	// Call SimConnect_TransmitClientEvent_EX1
+	hresult, _ := e.call(SimConnect_TransmitClientEvent_EX1, []any{objectID, eventID, groupID, flags, data},
+		uintptr(handle),    // hSimConnect
+		uintptr(objectID),  // ObjectID
+		uintptr(eventID),   // EventID
+		uintptr(groupID),   // GroupID
+		uintptr(flags),     // Flags
+		uintptr(params[0]), // dwData0
+		uintptr(params[1]), // dwData1
+		uintptr(params[2]), // dwData2
+		uintptr(params[3]), // dwData3
+		uintptr(params[4]), // dwData4
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: "SimConnect_TransmitClientEvent_EX1", Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// Send transmits a key event to the user aircraft by name, e.g. "AP_ALT_VAR_SET_ENGLISH" or "K:GEAR_UP"
+// The event is mapped to an SDK-allocated client event ID on first use and the mapping is cached.
+// A single parameter uses SimConnect_TransmitClientEvent, more use SimConnect_TransmitClientEvent_EX1.
+// Unknown names are reported asynchronously by SimConnect as a NAME_UNRECOGNIZED exception.
+func (e *Engine) Send(ctx context.Context, event types.KEvent, args ...uint32) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(args) > maxEventParams {
+		return fmt.Errorf("too many event parameters: %d (max %d)", len(args), maxEventParams)
+	}
+
+	eventID, err := e.keyEventID(event)
+	if err != nil {
+		return err
+	}
+
+	// Priority-addressed transmission needs no notification group of our own
+	groupID := types.NotificationGroupID(types.SIMCONNECT_GROUP_PRIORITY_HIGHEST)
+	flags := types.SIMCONNECT_EVENT_FLAG_GROUPID_IS_PRIORITY
+
+	if len(args) > 1 {
+		return e.TransmitClientEvent_EX1(types.SIMCONNECT_OBJECT_ID_USER, eventID, groupID, flags, args...)
+	}
+
+	var data uint32
+	if len(args) == 1 {
+		data = args[0]
+	}
+	return e.TransmitClientEvent(types.SIMCONNECT_OBJECT_ID_USER, eventID, data, groupID, flags)
+}
+
+// keyEventID returns the client event ID mapped to a key event, mapping it on first use
+func (e *Engine) keyEventID(event types.KEvent) (types.ClientEventID, error) {
+	name := event.Normalize()
+	if name == "" {
+		return 0, fmt.Errorf("empty event name")
+	}
+
+	e.mu.RLock()
+	eventID, exists := e.keyEvents[name]
+	e.mu.RUnlock()
+
+	if exists {
+		return eventID, nil
+	}
+
+	eventID = types.ClientEventID(e.nextInternalID())
+	if err := e.MapClientEventToSimEvent(eventID, string(name)); err != nil {
+		return 0, err
+	}
+
+	e.mu.Lock()
+	if existing, raced := e.keyEvents[name]; raced {
+		eventID = existing // Another caller mapped it first; both IDs reach the same event
+	} else {
+		e.keyEvents[name] = eventID
+	}
+	e.mu.Unlock()
+
+	return eventID, nil
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// EnumerateSimObjectsAndLiveries requests the installed SimObjects of a type with their liveries (MSFS 2024)
+// Pages arrive as "simobject_livery_list" messages; the aggregated list follows as "simobject_livery_list_complete"
+func (e *Engine) EnumerateSimObjectsAndLiveries(requestID uint32, objectType types.SimObjectType) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	// Get handle and reset any previous pages for this request (thread-safe)
+	e.mu.Lock()
+	delete(e.liveryPages, requestID)
+	handle := e.handle
+	e.mu.Unlock()
+
+	// Call SimConnect_EnumerateSimObjectsAndLiveries
+	hresult, _ := e.call(SimConnect_EnumerateSimObjectsAndLiveries, []any{requestID, objectType},
+		uintptr(handle),     // hSimConnect
+		uintptr(requestID),  // RequestID
+		uintptr(objectType), // Type
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: "SimConnect_EnumerateSimObjectsAndLiveries", Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// SimObjectsAndLiveries enumerates the installed SimObjects of a type and waits for all pages; Listen() must be active
+// Each entry pairs a SimObject title with one of its liveries
+func (e *Engine) SimObjectsAndLiveries(ctx context.Context, objectType types.SimObjectType) ([]types.SimObjectLivery, error) {
+	if err := e.ensureListening(); err != nil {
+		return nil, err
+	}
+
+	requestID := e.nextInternalID()
+	key := pendingKey{kind: "simobject_livery_list", id: requestID}
+	ch := e.expect(key)
+
+	if err := e.EnumerateSimObjectsAndLiveries(requestID, objectType); err != nil {
+		e.forget(key)
+		return nil, err
+	}
+
+	value, err := e.await(ctx, key, ch)
+
+	// One-shot request, its pages are no longer needed
+	e.mu.Lock()
+	delete(e.liveryPages, requestID)
+	e.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	return value.(*types.SimObjectLiveryList).Liveries, nil
+}
+
+// collectLiveryPage aggregates SimObject and livery pages, returning the full list after the last page
+func (e *Engine) collectLiveryPage(page *types.SimObjectLiveryList) *types.SimObjectLiveryList {
+	e.mu.Lock()
+	liveries, done := e.liveryPages.add(page.RequestID, page.Liveries, page.IsLastPage())
+	e.mu.Unlock()
+	if !done {
+		return nil
+	}
+
+	all := &types.SimObjectLiveryList{
+		RequestID:   page.RequestID,
+		EntryNumber: page.EntryNumber,
+		OutOf:       page.OutOf,
+		Liveries:    liveries,
+	}
+
+	e.resolve(pendingKey{kind: "simobject_livery_list", id: page.RequestID}, all)
+	return all
+}
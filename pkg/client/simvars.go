@@ -11,24 +11,30 @@ import (
 // RegisterSimVarDefinition registers a single simulation variable to a data definition with specified data type
 // This enhanced version tracks the data type for proper parsing later
 func (e *Engine) RegisterSimVarDefinition(defID uint32, varName string, units string, dataType types.SimConnectDataType) error {
+	_, err := e.registerSimVarDefinition(defID, varName, units, dataType)
+	return err
+}
+
+// registerSimVarDefinition is RegisterSimVarDefinition, also returning the ID of the packet it sent
+func (e *Engine) registerSimVarDefinition(defID uint32, varName string, units string, dataType types.SimConnectDataType) (uint32, error) {
 	// Thread-safe check for connection
 	e.system.mu.RLock()
 	isConnected := e.system.IsConnected
 	e.system.mu.RUnlock()
 
 	if !isConnected {
-		return fmt.Errorf("not connected to simulator")
+		return 0, ErrNotConnected
 	}
 
 	// Convert strings to C-style for SimConnect
 	varNamePtr, err := syscall.BytePtrFromString(varName)
 	if err != nil {
-		return fmt.Errorf("invalid variable name: %v", err)
+		return 0, fmt.Errorf("invalid variable name: %v", err)
 	}
 
 	unitsPtr, err := syscall.BytePtrFromString(units)
 	if err != nil {
-		return fmt.Errorf("invalid units: %v", err)
+		return 0, fmt.Errorf("invalid units: %v", err)
 	}
 
 	// Thread-safe access to handle
@@ -37,7 +43,7 @@ func (e *Engine) RegisterSimVarDefinition(defID uint32, varName string, units st
 	e.mu.RUnlock()
 
 	// Call SimConnect_AddToDataDefinition with the specified data type
-	hresult, _, _ := SimConnect_AddToDataDefinition.Call(
+	hresult, sendID := e.callField(SimConnect_AddToDataDefinition, varName, []any{defID, varName, units, dataType},
 		uintptr(handle),                     // hSimConnect
 		uintptr(defID),                      // DefineID
 		uintptr(unsafe.Pointer(varNamePtr)), // DatumName
@@ -48,7 +54,7 @@ func (e *Engine) RegisterSimVarDefinition(defID uint32, varName string, units st
 	)
 
 	if !IsHRESULTSuccess(uint32(hresult)) {
-		return fmt.Errorf("SimConnect_AddToDataDefinition failed: 0x%08X", uint32(hresult))
+		return 0, &HRESULTError{Function: "SimConnect_AddToDataDefinition", Code: uint32(hresult)}
 	}
 
 	// Store the data type mapping for later parsing (thread-safe)
@@ -56,19 +62,25 @@ func (e *Engine) RegisterSimVarDefinition(defID uint32, varName string, units st
 	e.dataTypeRegistry[defID] = dataType
 	e.mu.Unlock()
 
-	return nil
+	return sendID, nil
 }
 
 // RequestSimVarData requests data for a previously registered sim variable
 // This is the next baby step - actually get the data
 func (e *Engine) RequestSimVarData(defID uint32, requestID uint32) error {
+	_, err := e.requestSimVarData(defID, requestID)
+	return err
+}
+
+// requestSimVarData is RequestSimVarData, also returning the ID of the packet it sent
+func (e *Engine) requestSimVarData(defID uint32, requestID uint32) (uint32, error) {
 	// Thread-safe check for connection
 	e.system.mu.RLock()
 	isConnected := e.system.IsConnected
 	e.system.mu.RUnlock()
 
 	if !isConnected {
-		return fmt.Errorf("not connected to simulator")
+		return 0, ErrNotConnected
 	}
 
 	// Thread-safe access to handle
@@ -76,7 +88,7 @@ func (e *Engine) RequestSimVarData(defID uint32, requestID uint32) error {
 	handle := e.handle
 	e.mu.RUnlock()
 	// Call SimConnect_RequestDataOnSimObject
-	hresult, _, _ := SimConnect_RequestDataOnSimObject.Call(
+	hresult, sendID := e.call(SimConnect_RequestDataOnSimObject, []any{defID, requestID},
 		uintptr(handle),                                     // hSimConnect
 		uintptr(requestID),                                  // RequestID
 		uintptr(defID),                                      // DefineID
@@ -89,21 +101,27 @@ func (e *Engine) RequestSimVarData(defID uint32, requestID uint32) error {
 	)
 
 	if !IsHRESULTSuccess(uint32(hresult)) {
-		return fmt.Errorf("SimConnect_RequestDataOnSimObject failed: 0x%08X", uint32(hresult))
+		return 0, &HRESULTError{Function: "SimConnect_RequestDataOnSimObject", Code: uint32(hresult)}
 	}
-	return nil
+	return sendID, nil
 }
 
 // RequestSimVarDataPeriodic requests data for a previously registered sim variable with a specified frequency
 // This allows for continuous data updates at the specified period
 func (e *Engine) RequestSimVarDataPeriodic(defID uint32, requestID uint32, period types.SimConnectPeriod) error {
+	_, err := e.requestSimVarDataPeriodic(defID, requestID, period)
+	return err
+}
+
+// requestSimVarDataPeriodic is RequestSimVarDataPeriodic, also returning the ID of the packet it sent
+func (e *Engine) requestSimVarDataPeriodic(defID uint32, requestID uint32, period types.SimConnectPeriod) (uint32, error) {
 	// Thread-safe check for connection
 	e.system.mu.RLock()
 	isConnected := e.system.IsConnected
 	e.system.mu.RUnlock()
 
 	if !isConnected {
-		return fmt.Errorf("not connected to simulator")
+		return 0, ErrNotConnected
 	}
 
 	// Thread-safe access to handle
@@ -112,7 +130,7 @@ func (e *Engine) RequestSimVarDataPeriodic(defID uint32, requestID uint32, perio
 	e.mu.RUnlock()
 
 	// Call SimConnect_RequestDataOnSimObject with the specified period
-	hresult, _, _ := SimConnect_RequestDataOnSimObject.Call(
+	hresult, sendID := e.call(SimConnect_RequestDataOnSimObject, []any{defID, requestID, period},
 		uintptr(handle),                          // hSimConnect
 		uintptr(requestID),                       // RequestID
 		uintptr(defID),                           // DefineID
@@ -125,9 +143,9 @@ func (e *Engine) RequestSimVarDataPeriodic(defID uint32, requestID uint32, perio
 	)
 
 	if !IsHRESULTSuccess(uint32(hresult)) {
-		return fmt.Errorf("SimConnect_RequestDataOnSimObject periodic failed: 0x%08X", uint32(hresult))
+		return 0, &HRESULTError{Function: "SimConnect_RequestDataOnSimObject", Code: uint32(hresult)}
 	}
-	return nil
+	return sendID, nil
 }
 
 // StopPeriodicRequest stops a periodic data request by requesting it with SIMCONNECT_PERIOD_NEVER
@@ -138,7 +156,7 @@ func (e *Engine) StopPeriodicRequest(requestID uint32) error {
 	e.system.mu.RUnlock()
 
 	if !isConnected {
-		return fmt.Errorf("not connected to simulator")
+		return ErrNotConnected
 	}
 
 	// Thread-safe access to handle
@@ -147,7 +165,7 @@ func (e *Engine) StopPeriodicRequest(requestID uint32) error {
 	e.mu.RUnlock()
 
 	// Call SimConnect_RequestDataOnSimObject with NEVER period to stop updates
-	hresult, _, _ := SimConnect_RequestDataOnSimObject.Call(
+	hresult, _ := e.call(SimConnect_RequestDataOnSimObject, []any{requestID},
 		uintptr(handle),                          // hSimConnect
 		uintptr(requestID),                       // RequestID
 		0,                                        // DefineID (can be 0 when stopping)
@@ -160,7 +178,7 @@ func (e *Engine) StopPeriodicRequest(requestID uint32) error {
 	)
 
 	if !IsHRESULTSuccess(uint32(hresult)) {
-		return fmt.Errorf("SimConnect_RequestDataOnSimObject stop failed: 0x%08X", uint32(hresult))
+		return &HRESULTError{Function: "SimConnect_RequestDataOnSimObject", Code: uint32(hresult)}
 	}
 	return nil
 }
@@ -168,13 +186,19 @@ func (e *Engine) StopPeriodicRequest(requestID uint32) error {
 // SetSimVar sets data on a simulation object for a previously registered sim variable
 // Baby Step 3A: Generic method that uses the data type registry for proper type conversion
 func (e *Engine) SetSimVar(defID uint32, value interface{}) error {
+	_, err := e.setSimVar(defID, value)
+	return err
+}
+
+// setSimVar is SetSimVar, also returning the ID of the packet it sent
+func (e *Engine) setSimVar(defID uint32, value interface{}) (uint32, error) {
 	// Thread-safe check for connection
 	e.system.mu.RLock()
 	isConnected := e.system.IsConnected
 	e.system.mu.RUnlock()
 
 	if !isConnected {
-		return fmt.Errorf("not connected to simulator")
+		return 0, ErrNotConnected
 	}
 
 	// Look up the expected data type for this DefineID (thread-safe)
@@ -184,7 +208,7 @@ func (e *Engine) SetSimVar(defID uint32, value interface{}) error {
 	e.mu.RUnlock()
 
 	if !exists {
-		return fmt.Errorf("defID %d not found in data type registry - call RegisterSimVarDefinition first", defID)
+		return 0, fmt.Errorf("%w: defID %d not found in data type registry - call RegisterSimVarDefinition first", ErrUnknownDefinition, defID)
 	}
 	// Convert the value to the proper binary format based on data type
 	var dataPtr unsafe.Pointer
@@ -192,7 +216,7 @@ func (e *Engine) SetSimVar(defID uint32, value interface{}) error {
 
 	switch dataType {
 	case types.SIMCONNECT_DATATYPE_INVALID:
-		return fmt.Errorf("cannot set data with INVALID data type for defID %d", defID)
+		return 0, fmt.Errorf("cannot set data with INVALID data type for defID %d", defID)
 
 	case types.SIMCONNECT_DATATYPE_INT32:
 		var int32Value int32
@@ -208,7 +232,7 @@ func (e *Engine) SetSimVar(defID uint32, value interface{}) error {
 		case float32:
 			int32Value = int32(v)
 		default:
-			return fmt.Errorf("cannot convert %T to int32 for defID %d", value, defID)
+			return 0, fmt.Errorf("cannot convert %T to int32 for defID %d", value, defID)
 		}
 		dataPtr = unsafe.Pointer(&int32Value)
 		dataSize = 4
@@ -227,7 +251,7 @@ func (e *Engine) SetSimVar(defID uint32, value interface{}) error {
 		case float32:
 			int64Value = int64(v)
 		default:
-			return fmt.Errorf("cannot convert %T to int64 for defID %d", value, defID)
+			return 0, fmt.Errorf("cannot convert %T to int64 for defID %d", value, defID)
 		}
 		dataPtr = unsafe.Pointer(&int64Value)
 		dataSize = 8
@@ -246,7 +270,7 @@ func (e *Engine) SetSimVar(defID uint32, value interface{}) error {
 		case int64:
 			float32Value = float32(v)
 		default:
-			return fmt.Errorf("cannot convert %T to float32 for defID %d", value, defID)
+			return 0, fmt.Errorf("cannot convert %T to float32 for defID %d", value, defID)
 		}
 		dataPtr = unsafe.Pointer(&float32Value)
 		dataSize = 4
@@ -265,7 +289,7 @@ func (e *Engine) SetSimVar(defID uint32, value interface{}) error {
 		case int64:
 			float64Value = float64(v)
 		default:
-			return fmt.Errorf("cannot convert %T to float64 for defID %d", value, defID)
+			return 0, fmt.Errorf("cannot convert %T to float64 for defID %d", value, defID)
 		}
 		dataPtr = unsafe.Pointer(&float64Value)
 		dataSize = 8
@@ -276,7 +300,7 @@ func (e *Engine) SetSimVar(defID uint32, value interface{}) error {
 		case string:
 			stringValue = v
 		default:
-			return fmt.Errorf("cannot convert %T to string for defID %d", value, defID)
+			return 0, fmt.Errorf("cannot convert %T to string for defID %d", value, defID)
 		}
 		// For variable strings, include null terminator
 		stringBytes := []byte(stringValue + "\x00")
@@ -286,7 +310,7 @@ func (e *Engine) SetSimVar(defID uint32, value interface{}) error {
 	case types.SIMCONNECT_DATATYPE_STRING8:
 		stringBytes, err := e.prepareFixedString(value, 8, defID)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		dataPtr = unsafe.Pointer(&stringBytes[0])
 		dataSize = 8
@@ -294,7 +318,7 @@ func (e *Engine) SetSimVar(defID uint32, value interface{}) error {
 	case types.SIMCONNECT_DATATYPE_STRING32:
 		stringBytes, err := e.prepareFixedString(value, 32, defID)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		dataPtr = unsafe.Pointer(&stringBytes[0])
 		dataSize = 32
@@ -302,7 +326,7 @@ func (e *Engine) SetSimVar(defID uint32, value interface{}) error {
 	case types.SIMCONNECT_DATATYPE_STRING64:
 		stringBytes, err := e.prepareFixedString(value, 64, defID)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		dataPtr = unsafe.Pointer(&stringBytes[0])
 		dataSize = 64
@@ -310,7 +334,7 @@ func (e *Engine) SetSimVar(defID uint32, value interface{}) error {
 	case types.SIMCONNECT_DATATYPE_STRING128:
 		stringBytes, err := e.prepareFixedString(value, 128, defID)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		dataPtr = unsafe.Pointer(&stringBytes[0])
 		dataSize = 128
@@ -318,7 +342,7 @@ func (e *Engine) SetSimVar(defID uint32, value interface{}) error {
 	case types.SIMCONNECT_DATATYPE_STRING256:
 		stringBytes, err := e.prepareFixedString(value, 256, defID)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		dataPtr = unsafe.Pointer(&stringBytes[0])
 		dataSize = 256
@@ -326,7 +350,7 @@ func (e *Engine) SetSimVar(defID uint32, value interface{}) error {
 	case types.SIMCONNECT_DATATYPE_STRING260:
 		stringBytes, err := e.prepareFixedString(value, 260, defID)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		dataPtr = unsafe.Pointer(&stringBytes[0])
 		dataSize = 260
@@ -334,7 +358,7 @@ func (e *Engine) SetSimVar(defID uint32, value interface{}) error {
 	case types.SIMCONNECT_DATATYPE_INITPOSITION:
 		initPos, err := e.prepareInitPosition(value, defID)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		dataPtr = unsafe.Pointer(initPos)
 		dataSize = uint32(unsafe.Sizeof(types.InitPosition{}))
@@ -342,7 +366,7 @@ func (e *Engine) SetSimVar(defID uint32, value interface{}) error {
 	case types.SIMCONNECT_DATATYPE_MARKERSTATE:
 		markerState, err := e.prepareMarkerState(value, defID)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		dataPtr = unsafe.Pointer(markerState)
 		dataSize = uint32(unsafe.Sizeof(types.MarkerState{}))
@@ -350,7 +374,7 @@ func (e *Engine) SetSimVar(defID uint32, value interface{}) error {
 	case types.SIMCONNECT_DATATYPE_WAYPOINT:
 		waypoint, err := e.prepareWaypoint(value, defID)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		dataPtr = unsafe.Pointer(waypoint)
 		dataSize = uint32(unsafe.Sizeof(types.Waypoint{}))
@@ -358,7 +382,7 @@ func (e *Engine) SetSimVar(defID uint32, value interface{}) error {
 	case types.SIMCONNECT_DATATYPE_LATLONALT:
 		latLonAlt, err := e.prepareLatLonAlt(value, defID)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		dataPtr = unsafe.Pointer(latLonAlt)
 		dataSize = uint32(unsafe.Sizeof(types.LatLonAlt{}))
@@ -366,17 +390,17 @@ func (e *Engine) SetSimVar(defID uint32, value interface{}) error {
 	case types.SIMCONNECT_DATATYPE_XYZ:
 		xyz, err := e.prepareXYZ(value, defID)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		dataPtr = unsafe.Pointer(xyz)
 		dataSize = uint32(unsafe.Sizeof(types.XYZ{}))
 
 	default:
-		return fmt.Errorf("unsupported data type %d for defID %d", dataType, defID)
+		return 0, fmt.Errorf("unsupported data type %d for defID %d", dataType, defID)
 	}
 
 	// Call SimConnect_SetDataOnSimObject
-	hresult, _, _ := SimConnect_SetDataOnSimObject.Call(
+	hresult, sendID := e.call(SimConnect_SetDataOnSimObject, []any{defID, value},
 		uintptr(handle),                                 // hSimConnect
 		uintptr(defID),                                  // DefineID
 		uintptr(types.SIMCONNECT_OBJECT_ID_USER),        // ObjectID (user aircraft)
@@ -387,10 +411,10 @@ func (e *Engine) SetSimVar(defID uint32, value interface{}) error {
 	)
 
 	if !IsHRESULTSuccess(uint32(hresult)) {
-		return fmt.Errorf("SimConnect_SetDataOnSimObject failed: 0x%08X", uint32(hresult))
+		return 0, &HRESULTError{Function: "SimConnect_SetDataOnSimObject", Code: uint32(hresult)}
 	}
 
-	return nil
+	return sendID, nil
 }
 
 // Helper functions for preparing complex data types for SetSimVar
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// RequestSystemStateData requests a system state; the reply arrives as a "system_state" message with requestID
+func (e *Engine) RequestSystemStateData(requestID uint32, state types.SystemStateName) error {
+	// Thread-safe check for connection
+	e.system.mu.RLock()
+	isConnected := e.system.IsConnected
+	e.system.mu.RUnlock()
+
+	if !isConnected {
+		return ErrNotConnected
+	}
+
+	statePtr, err := syscall.BytePtrFromString(string(state))
+	if err != nil {
+		return fmt.Errorf("invalid system state name: %v", err)
+	}
+
+	// Get handle (thread-safe)
+	e.mu.RLock()
+	handle := e.handle
+	e.mu.RUnlock()
+
+	// Call SimConnect_RequestSystemState
+	hresult, _ := e.call(SimConnect_RequestSystemState, []any{requestID, state},
+		uintptr(handle),                   // hSimConnect
+		uintptr(requestID),                // RequestID
+		uintptr(unsafe.Pointer(statePtr)), // szState
+	)
+
+	if !IsHRESULTSuccess(uint32(hresult)) {
+		return &HRESULTError{Function: "SimConnect_RequestSystemState", Code: uint32(hresult)}
+	}
+	return nil
+}
+
+// RequestSystemState requests a system state and waits for the reply; Listen() must be active
+func (e *Engine) RequestSystemState(ctx context.Context, state types.SystemStateName) (*types.SystemStateData, error) {
+	if err := e.ensureListening(); err != nil {
+		return nil, err
+	}
+
+	requestID := e.nextInternalID()
+	key := pendingKey{kind: "system_state", id: requestID}
+	ch := e.expect(key)
+
+	if err := e.RequestSystemStateData(requestID, state); err != nil {
+		e.forget(key)
+		return nil, err
+	}
+
+	value, err := e.await(ctx, key, ch)
+	if err != nil {
+		return nil, err
+	}
+	return value.(*types.SystemStateData), nil
+}
+
+// RequestAircraftLoaded returns the full path of the loaded aircraft .CFG
+func (e *Engine) RequestAircraftLoaded(ctx context.Context) (string, error) {
+	return e.requestSystemStatePath(ctx, types.SIMCONNECT_SYSTEM_STATE_AIRCRAFT_LOADED)
+}
+
+// RequestFlightLoaded returns the full path of the loaded .FLT
+func (e *Engine) RequestFlightLoaded(ctx context.Context) (string, error) {
+	return e.requestSystemStatePath(ctx, types.SIMCONNECT_SYSTEM_STATE_FLIGHT_LOADED)
+}
+
+// RequestFlightPlan returns the full path of the active .PLN, empty when no plan is loaded
+func (e *Engine) RequestFlightPlan(ctx context.Context) (string, error) {
+	return e.requestSystemStatePath(ctx, types.SIMCONNECT_SYSTEM_STATE_FLIGHT_PLAN)
+}
+
+// RequestDialogMode reports whether a simulator dialog is open
+func (e *Engine) RequestDialogMode(ctx context.Context) (bool, error) {
+	return e.requestSystemStateBool(ctx, types.SIMCONNECT_SYSTEM_STATE_DIALOG_MODE)
+}
+
+// RequestSimRunning reports whether the user is in control of the aircraft (the "Sim" state)
+func (e *Engine) RequestSimRunning(ctx context.Context) (bool, error) {
+	return e.requestSystemStateBool(ctx, types.SIMCONNECT_SYSTEM_STATE_SIM)
+}
+
+// requestSystemStatePath requests a string system state
+func (e *Engine) requestSystemStatePath(ctx context.Context, state types.SystemStateName) (string, error) {
+	data, err := e.RequestSystemState(ctx, state)
+	if err != nil {
+		return "", err
+	}
+	return data.Path(), nil
+}
+
+// requestSystemStateBool requests an integer system state
+func (e *Engine) requestSystemStateBool(ctx context.Context, state types.SystemStateName) (bool, error) {
+	data, err := e.RequestSystemState(ctx, state)
+	if err != nil {
+		return false, err
+	}
+	return data.Bool(), nil
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// verify waits for an exception raised by the packet with the given send ID; Listen() must be active.
+// The window ends at the context deadline, or after DEFAULT_VERIFY_TIMEOUT when there is none;
+// a packet without an exception by then is taken as accepted.
+func (e *Engine) verify(ctx context.Context, sendID uint32) error {
+	if sendID == 0 {
+		// The packet ID was unavailable, so an exception could not be tied to the call
+		return nil
+	}
+
+	key := pendingKey{kind: "exception", id: sendID}
+	ch := e.expect(key)
+
+	// The exception may have been dispatched before the registration (see annotateException)
+	if exception := e.packets.exception(sendID); exception != nil {
+		e.forget(key)
+		return &ExceptionError{Exception: exception}
+	}
+
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DEFAULT_VERIFY_TIMEOUT)
+		defer cancel()
+	}
+
+	value, err := e.await(ctx, key, ch)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return &ExceptionError{Exception: value.(*types.ExceptionData)}
+}
+
+// RegisterSimVarDefinitionAndVerify is RegisterSimVarDefinition, also returning an exception the simulator
+// raises for it, e.g. for an unknown variable name, as an *ExceptionError; Listen() must be active
+func (e *Engine) RegisterSimVarDefinitionAndVerify(ctx context.Context, defID uint32, varName string, units string, dataType types.SimConnectDataType) error {
+	if err := e.ensureListening(); err != nil {
+		return err
+	}
+
+	sendID, err := e.registerSimVarDefinition(defID, varName, units, dataType)
+	if err != nil {
+		return err
+	}
+	return e.verify(ctx, sendID)
+}
+
+// RequestSimVarDataAndVerify is RequestSimVarData, also returning an exception the simulator raises for it
+// as an *ExceptionError; Listen() must be active
+func (e *Engine) RequestSimVarDataAndVerify(ctx context.Context, defID uint32, requestID uint32) error {
+	if err := e.ensureListening(); err != nil {
+		return err
+	}
+
+	sendID, err := e.requestSimVarData(defID, requestID)
+	if err != nil {
+		return err
+	}
+	return e.verify(ctx, sendID)
+}
+
+// RequestSimVarDataPeriodicAndVerify is RequestSimVarDataPeriodic, also returning an exception the simulator
+// raises for it as an *ExceptionError; Listen() must be active
+func (e *Engine) RequestSimVarDataPeriodicAndVerify(ctx context.Context, defID uint32, requestID uint32, period types.SimConnectPeriod) error {
+	if err := e.ensureListening(); err != nil {
+		return err
+	}
+
+	sendID, err := e.requestSimVarDataPeriodic(defID, requestID, period)
+	if err != nil {
+		return err
+	}
+	return e.verify(ctx, sendID)
+}
+
+// SetSimVarAndVerify is SetSimVar, also returning an exception the simulator raises for it,
+// e.g. for a read-only variable, as an *ExceptionError; Listen() must be active
+func (e *Engine) SetSimVarAndVerify(ctx context.Context, defID uint32, value interface{}) error {
+	if err := e.ensureListening(); err != nil {
+		return err
+	}
+
+	sendID, err := e.setSimVar(defID, value)
+	if err != nil {
+		return err
+	}
+	return e.verify(ctx, sendID)
+}
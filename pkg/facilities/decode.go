@@ -0,0 +1,193 @@
+package facilities
+
+import (
+	"fmt"
+
+	"github.com/mycrew-online/sdk/internal/wire"
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// Node is one decoded facility record together with its nested child records
+type Node struct {
+	Scope     string                 `json:"scope"`      // Scope name from the definition, e.g. "PRIMARY_THRESHOLD"
+	Type      types.FacilityDataType `json:"type"`       // Record type reported by SimConnect
+	ItemIndex uint32                 `json:"item_index"` // Index of the record within its list
+	Fields    map[string]any         `json:"fields"`     // Field values: int32, float32, float64 or string
+	Children  []*Node                `json:"children,omitempty"`
+
+	uniqueID uint32
+	scope    *Scope
+}
+
+// Int returns an integer field, or 0 when absent
+func (n *Node) Int(name string) int32 {
+	if v, ok := n.Fields[name].(int32); ok {
+		return v
+	}
+	return 0
+}
+
+// Float returns a floating point field widened to float64, or 0 when absent
+func (n *Node) Float(name string) float64 {
+	switch v := n.Fields[name].(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	default:
+		return 0
+	}
+}
+
+// String returns a string field, or "" when absent
+func (n *Node) String(name string) string {
+	if v, ok := n.Fields[name].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// ChildrenOf returns the direct children with the given scope name
+func (n *Node) ChildrenOf(scope string) []*Node {
+	var out []*Node
+	for _, c := range n.Children {
+		if c.Scope == scope {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Child returns the first direct child with the given scope name, or nil
+func (n *Node) Child(scope string) *Node {
+	for _, c := range n.Children {
+		if c.Scope == scope {
+			return c
+		}
+	}
+	return nil
+}
+
+// Result is a completed facility request, emitted once FACILITY_DATA_END arrives
+type Result struct {
+	RequestID uint32 `json:"request_id"`
+	Root      *Node  `json:"root"`
+}
+
+// DecodeRecord decodes the raw field values of a single record laid out by scope
+func DecodeRecord(scope *Scope, data []byte) (map[string]any, error) {
+	if len(data) < scope.Size() {
+		return nil, fmt.Errorf("facility record %s too short: got %d bytes, need %d", scope.Name, len(data), scope.Size())
+	}
+	r := wire.NewReader(data)
+	fields := make(map[string]any, len(scope.Fields))
+	for _, f := range scope.Fields {
+		switch f.Kind {
+		case KindInt32:
+			fields[f.Name] = r.Int32()
+		case KindFloat32:
+			fields[f.Name] = r.Float32()
+		case KindFloat64:
+			fields[f.Name] = r.Float64()
+		case KindString8:
+			fields[f.Name] = r.String(8)
+		case KindString32:
+			fields[f.Name] = r.String(32)
+		case KindString64:
+			fields[f.Name] = r.String(64)
+		}
+	}
+	return fields, nil
+}
+
+// Assembler rebuilds the record tree of one facility request from its FACILITY_DATA messages
+type Assembler struct {
+	def   *Definition
+	nodes map[uint32]*Node
+	roots []*Node
+}
+
+// NewAssembler creates an assembler for records requested with def
+func NewAssembler(def *Definition) *Assembler {
+	return &Assembler{
+		def:   def,
+		nodes: make(map[uint32]*Node),
+	}
+}
+
+// Add decodes a record and attaches it to its parent
+func (a *Assembler) Add(rec *types.FacilityData) (*Node, error) {
+	parent := a.nodes[rec.ParentID]
+
+	var candidates []*Scope
+	if parent != nil {
+		candidates = parent.scope.Children
+	} else {
+		candidates = a.def.Roots()
+	}
+	scope := pickScope(candidates, rec.Type, parent)
+	if scope == nil {
+		return nil, fmt.Errorf("no %s scope in definition for record %d", types.GetFacilityDataTypeName(rec.Type), rec.UniqueRequestID)
+	}
+
+	fields, err := DecodeRecord(scope, rec.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &Node{
+		Scope:     scope.Name,
+		Type:      rec.Type,
+		ItemIndex: rec.ItemIndex,
+		Fields:    fields,
+		uniqueID:  rec.UniqueRequestID,
+		scope:     scope,
+	}
+	a.nodes[rec.UniqueRequestID] = node
+	if parent != nil {
+		parent.Children = append(parent.Children, node)
+	} else {
+		a.roots = append(a.roots, node)
+	}
+	return node, nil
+}
+
+// pickScope finds the definition scope a record belongs to. Sibling scopes may share a
+// record type (PRIMARY_THRESHOLD and SECONDARY_THRESHOLD are both PAVEMENT); SimConnect
+// sends them in declaration order, so the n-th record of a type maps to the n-th scope.
+func pickScope(candidates []*Scope, t types.FacilityDataType, parent *Node) *Scope {
+	var matches []*Scope
+	for _, s := range candidates {
+		if s.Type == t {
+			matches = append(matches, s)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil
+	case 1:
+		return matches[0]
+	}
+	seen := 0
+	if parent != nil {
+		for _, c := range parent.Children {
+			if c.Type == t {
+				seen++
+			}
+		}
+	}
+	return matches[seen%len(matches)]
+}
+
+// Roots returns all top-level records received so far
+func (a *Assembler) Roots() []*Node {
+	return a.roots
+}
+
+// Root returns the first top-level record, or nil if none arrived
+func (a *Assembler) Root() *Node {
+	if len(a.roots) == 0 {
+		return nil
+	}
+	return a.roots[0]
+}
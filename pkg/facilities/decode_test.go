@@ -0,0 +1,218 @@
+package facilities
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// payload builds a FACILITY_DATA record the way SimConnect lays it out: packed little-endian values
+// and NUL-padded fixed-size strings
+type payload []byte
+
+func (p payload) i32(v int32) payload {
+	return binary.LittleEndian.AppendUint32(p, uint32(v))
+}
+
+func (p payload) f32(v float32) payload {
+	return binary.LittleEndian.AppendUint32(p, math.Float32bits(v))
+}
+
+func (p payload) f64(v float64) payload {
+	return binary.LittleEndian.AppendUint64(p, math.Float64bits(v))
+}
+
+func (p payload) str(size int, v string) payload {
+	b := make([]byte, size)
+	copy(b, v)
+	return append(p, b...)
+}
+
+func TestDecodeRecord(t *testing.T) {
+	scope := &Scope{Name: "TEST", Fields: []Field{
+		{"I", KindInt32}, {"F", KindFloat32}, {"D", KindFloat64},
+		{"S8", KindString8}, {"S32", KindString32}, {"S64", KindString64},
+	}}
+	data := payload{}.i32(-7).f32(1.5).f64(47.458056).str(8, "LSZH").str(32, "ZURICH").str(64, "Zurich Kloten")
+
+	fields, err := DecodeRecord(scope, data)
+	if err != nil {
+		t.Fatalf("DecodeRecord: %v", err)
+	}
+	want := map[string]any{
+		"I": int32(-7), "F": float32(1.5), "D": 47.458056,
+		"S8": "LSZH", "S32": "ZURICH", "S64": "Zurich Kloten",
+	}
+	for name, v := range want {
+		if fields[name] != v {
+			t.Errorf("%s = %#v, want %#v", name, fields[name], v)
+		}
+	}
+
+	if _, err := DecodeRecord(scope, data[:len(data)-1]); err == nil {
+		t.Error("DecodeRecord of a short record succeeded")
+	}
+}
+
+func TestDefinitionLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     *Definition
+		want    string
+		wantErr string
+	}{
+		{
+			name: "nested scopes",
+			def: NewDefinition().
+				Open("AIRPORT").Fields("ICAO", "LATITUDE").
+				Open("RUNWAY").Fields("HEADING").
+				Open("PRIMARY_THRESHOLD").Fields("LENGTH").Close().
+				Close().
+				Close(),
+			want: "OPEN AIRPORT,ICAO,LATITUDE,OPEN RUNWAY,HEADING,OPEN PRIMARY_THRESHOLD,LENGTH,CLOSE PRIMARY_THRESHOLD,CLOSE RUNWAY,CLOSE AIRPORT",
+		},
+		{
+			name: "undocumented field",
+			def:  NewDefinition().Open("VOR").FieldOfKind("IS_TRUE_REFERENCED", KindInt32).Close(),
+			want: "OPEN VOR,IS_TRUE_REFERENCED,CLOSE VOR",
+		},
+		{name: "unknown scope", def: NewDefinition().Open("GATE").Close(), wantErr: `unknown facility scope "GATE"`},
+		{name: "unknown field", def: NewDefinition().Open("AIRPORT").Fields("FREQUENCY").Close(), wantErr: `unknown field "FREQUENCY"`},
+		{name: "field outside scope", def: NewDefinition().Fields("ICAO"), wantErr: "outside of an open scope"},
+		{name: "unclosed scope", def: NewDefinition().Open("AIRPORT").Fields("ICAO"), wantErr: "AIRPORT is not closed"},
+		{name: "close without open", def: NewDefinition().Close(), wantErr: "close without a matching open"},
+		{name: "empty", def: NewDefinition(), wantErr: "empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := tt.def.Lines()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Lines: %v", err)
+			}
+			if got := strings.Join(lines, ","); got != tt.want {
+				t.Errorf("Lines = %s\nwant    %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// runwayDefinition requests an airport with its runways, both thresholds and frequencies
+func runwayDefinition() *Definition {
+	return NewDefinition().
+		Open("AIRPORT").Fields("ICAO", "LATITUDE", "N_RUNWAYS").
+		Open("RUNWAY").Fields("HEADING", "LENGTH").
+		Open("PRIMARY_THRESHOLD").Fields("LENGTH").Close().
+		Open("SECONDARY_THRESHOLD").Fields("LENGTH").Close().
+		Close().
+		Open("FREQUENCY").Fields("TYPE", "FREQUENCY", "NAME").Close().
+		Close()
+}
+
+func TestAssemblerTree(t *testing.T) {
+	records := []types.FacilityData{
+		{UniqueRequestID: 1, Type: types.SIMCONNECT_FACILITY_DATA_AIRPORT,
+			Data: payload{}.str(8, "LSZH").f64(47.458056).i32(2)},
+		{UniqueRequestID: 2, ParentID: 1, Type: types.SIMCONNECT_FACILITY_DATA_RUNWAY, IsListItem: true, ItemIndex: 0, ListSize: 2,
+			Data: payload{}.f32(95).f32(2500)},
+		{UniqueRequestID: 3, ParentID: 2, Type: types.SIMCONNECT_FACILITY_DATA_PAVEMENT, Data: payload{}.f32(60)},
+		{UniqueRequestID: 4, ParentID: 2, Type: types.SIMCONNECT_FACILITY_DATA_PAVEMENT, Data: payload{}.f32(0)},
+		{UniqueRequestID: 5, ParentID: 1, Type: types.SIMCONNECT_FACILITY_DATA_RUNWAY, IsListItem: true, ItemIndex: 1, ListSize: 2,
+			Data: payload{}.f32(155).f32(3700)},
+		{UniqueRequestID: 6, ParentID: 5, Type: types.SIMCONNECT_FACILITY_DATA_PAVEMENT, Data: payload{}.f32(150)},
+		{UniqueRequestID: 7, ParentID: 5, Type: types.SIMCONNECT_FACILITY_DATA_PAVEMENT, Data: payload{}.f32(30)},
+		{UniqueRequestID: 8, ParentID: 1, Type: types.SIMCONNECT_FACILITY_DATA_FREQUENCY, IsListItem: true, ListSize: 1,
+			Data: payload{}.i32(8).i32(118100000).str(64, "ZURICH TOWER")},
+	}
+
+	a := NewAssembler(runwayDefinition())
+	for i := range records {
+		if _, err := a.Add(&records[i]); err != nil {
+			t.Fatalf("Add record %d: %v", records[i].UniqueRequestID, err)
+		}
+	}
+
+	root := a.Root()
+	if root == nil || len(a.Roots()) != 1 {
+		t.Fatalf("roots = %v, want one airport", a.Roots())
+	}
+	if root.Scope != "AIRPORT" || root.String("ICAO") != "LSZH" || root.Float("LATITUDE") != 47.458056 || root.Int("N_RUNWAYS") != 2 {
+		t.Errorf("airport = %+v", root)
+	}
+
+	runways := root.ChildrenOf("RUNWAY")
+	if len(runways) != 2 {
+		t.Fatalf("len(runways) = %d, want 2", len(runways))
+	}
+	tests := []struct {
+		heading, primary, secondary float64
+	}{
+		{95, 60, 0},
+		{155, 150, 30},
+	}
+	for i, tt := range tests {
+		rwy := runways[i]
+		if rwy.ItemIndex != uint32(i) || rwy.Float("HEADING") != tt.heading {
+			t.Errorf("runway %d = %+v", i, rwy)
+		}
+		// Both thresholds are PAVEMENT records; declaration order decides which is which, per runway
+		if len(rwy.Children) != 2 {
+			t.Fatalf("runway %d has %d children, want 2", i, len(rwy.Children))
+		}
+		if p := rwy.Child("PRIMARY_THRESHOLD"); p == nil || p.Float("LENGTH") != tt.primary {
+			t.Errorf("runway %d primary threshold = %+v, want length %v", i, p, tt.primary)
+		}
+		if s := rwy.Child("SECONDARY_THRESHOLD"); s == nil || s.Float("LENGTH") != tt.secondary {
+			t.Errorf("runway %d secondary threshold = %+v, want length %v", i, s, tt.secondary)
+		}
+	}
+
+	freq := root.Child("FREQUENCY")
+	if freq == nil || freq.Int("FREQUENCY") != 118100000 || freq.String("NAME") != "ZURICH TOWER" {
+		t.Errorf("frequency = %+v", freq)
+	}
+}
+
+func TestAssemblerRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		rec  types.FacilityData
+	}{
+		{
+			name: "type missing from the definition",
+			rec:  types.FacilityData{UniqueRequestID: 2, ParentID: 1, Type: types.SIMCONNECT_FACILITY_DATA_TAXI_PARKING, Data: make([]byte, 64)},
+		},
+		{
+			name: "scope only valid under another parent",
+			rec:  types.FacilityData{UniqueRequestID: 2, ParentID: 1, Type: types.SIMCONNECT_FACILITY_DATA_PAVEMENT, Data: payload{}.f32(60)},
+		},
+		{
+			name: "truncated record",
+			rec:  types.FacilityData{UniqueRequestID: 2, ParentID: 1, Type: types.SIMCONNECT_FACILITY_DATA_RUNWAY, Data: payload{}.f32(95)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAssembler(runwayDefinition())
+			airport := types.FacilityData{UniqueRequestID: 1, Type: types.SIMCONNECT_FACILITY_DATA_AIRPORT,
+				Data: payload{}.str(8, "LSZH").f64(47.458056).i32(2)}
+			if _, err := a.Add(&airport); err != nil {
+				t.Fatalf("Add airport: %v", err)
+			}
+			if _, err := a.Add(&tt.rec); err == nil {
+				t.Error("Add succeeded")
+			}
+			if len(a.Root().Children) != 0 {
+				t.Errorf("rejected record was attached: %+v", a.Root().Children)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package facilities
+
+import (
+	"fmt"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// Field is a single value requested inside a facility scope
+type Field struct {
+	Name string
+	Kind FieldKind
+}
+
+// Scope is an OPEN/CLOSE block of a facility definition, e.g. AIRPORT or RUNWAY
+type Scope struct {
+	Name     string
+	Type     types.FacilityDataType
+	Fields   []Field
+	Children []*Scope
+	parent   *Scope
+}
+
+// Size returns the number of bytes a record of this scope occupies in the data stream
+func (s *Scope) Size() int {
+	size := 0
+	for _, f := range s.Fields {
+		size += f.Kind.Size()
+	}
+	return size
+}
+
+// Definition describes the nested facility fields a caller wants, mirroring the
+// sequence of SimConnect_AddToFacilityDefinition calls ("OPEN AIRPORT", "ICAO", ..., "CLOSE AIRPORT").
+// Builder methods record the first error and can be chained freely.
+type Definition struct {
+	roots []*Scope
+	open  *Scope
+	lines []string
+	err   error
+}
+
+// NewDefinition creates an empty facility definition
+func NewDefinition() *Definition {
+	return &Definition{}
+}
+
+// Open starts a nested scope such as "AIRPORT", "RUNWAY" or "TAXI_PARKING"
+func (d *Definition) Open(name string) *Definition {
+	if d.err != nil {
+		return d
+	}
+	t, ok := scopeTypes[name]
+	if !ok {
+		d.err = fmt.Errorf("unknown facility scope %q", name)
+		return d
+	}
+	scope := &Scope{Name: name, Type: t, parent: d.open}
+	if d.open == nil {
+		d.roots = append(d.roots, scope)
+	} else {
+		d.open.Children = append(d.open.Children, scope)
+	}
+	d.open = scope
+	d.lines = append(d.lines, "OPEN "+name)
+	return d
+}
+
+// Fields adds documented fields to the currently open scope
+func (d *Definition) Fields(names ...string) *Definition {
+	for _, name := range names {
+		if d.err != nil {
+			return d
+		}
+		if d.open == nil {
+			d.err = fmt.Errorf("field %q declared outside of an open scope", name)
+			return d
+		}
+		kind, ok := lookupField(d.open.Type, name)
+		if !ok {
+			d.err = fmt.Errorf("unknown field %q in scope %s - use FieldOfKind for undocumented fields", name, d.open.Name)
+			return d
+		}
+		d.FieldOfKind(name, kind)
+	}
+	return d
+}
+
+// FieldOfKind adds a field with an explicit encoding to the currently open scope
+func (d *Definition) FieldOfKind(name string, kind FieldKind) *Definition {
+	if d.err != nil {
+		return d
+	}
+	if d.open == nil {
+		d.err = fmt.Errorf("field %q declared outside of an open scope", name)
+		return d
+	}
+	if kind.Size() == 0 {
+		d.err = fmt.Errorf("invalid kind %d for field %q", kind, name)
+		return d
+	}
+	d.open.Fields = append(d.open.Fields, Field{Name: name, Kind: kind})
+	d.lines = append(d.lines, name)
+	return d
+}
+
+// Close ends the currently open scope
+func (d *Definition) Close() *Definition {
+	if d.err != nil {
+		return d
+	}
+	if d.open == nil {
+		d.err = fmt.Errorf("close without a matching open")
+		return d
+	}
+	d.lines = append(d.lines, "CLOSE "+d.open.Name)
+	d.open = d.open.parent
+	return d
+}
+
+// Lines returns the strings to pass to SimConnect_AddToFacilityDefinition, in order
+func (d *Definition) Lines() ([]string, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	if d.open != nil {
+		return nil, fmt.Errorf("facility scope %s is not closed", d.open.Name)
+	}
+	if len(d.roots) == 0 {
+		return nil, fmt.Errorf("facility definition is empty")
+	}
+	lines := make([]string, len(d.lines))
+	copy(lines, d.lines)
+	return lines, nil
+}
+
+// Roots returns the top-level scopes of the definition
+func (d *Definition) Roots() []*Scope {
+	return d.roots
+}
+
+// Err returns the first error recorded while building the definition
+func (d *Definition) Err() error {
+	return d.err
+}
+
+// AirportDefinition returns a ready-made definition covering what an EFB typically needs:
+// airport identity and position, runways with both ends and ILS references,
+// frequencies, and parking spots.
+func AirportDefinition() *Definition {
+	return NewDefinition().
+		Open("AIRPORT").
+		Fields("ICAO", "REGION", "NAME64", "LATITUDE", "LONGITUDE", "ALTITUDE", "MAGVAR",
+			"TRANSITION_ALTITUDE", "N_RUNWAYS", "N_FREQUENCIES", "N_TAXI_PARKINGS").
+		Open("RUNWAY").
+		Fields("LATITUDE", "LONGITUDE", "ALTITUDE", "HEADING", "LENGTH", "WIDTH", "SURFACE",
+			"PRIMARY_NUMBER", "PRIMARY_DESIGNATOR", "PRIMARY_ILS_ICAO", "PRIMARY_ILS_REGION", "PRIMARY_ILS_TYPE",
+			"SECONDARY_NUMBER", "SECONDARY_DESIGNATOR", "SECONDARY_ILS_ICAO", "SECONDARY_ILS_REGION", "SECONDARY_ILS_TYPE").
+		Open("PRIMARY_THRESHOLD").Fields("LENGTH").Close().
+		Open("SECONDARY_THRESHOLD").Fields("LENGTH").Close().
+		Close().
+		Open("FREQUENCY").
+		Fields("TYPE", "FREQUENCY", "NAME").
+		Close().
+		Open("TAXI_PARKING").
+		Fields("TYPE", "NAME", "SUFFIX", "NUMBER", "HEADING", "RADIUS", "BIAS_X", "BIAS_Z").
+		Close().
+		Close()
+}
+
+// NavaidDefinition returns a definition for a VOR/ILS/DME navaid including its frequency
+func NavaidDefinition() *Definition {
+	return NewDefinition().
+		Open("VOR").
+		Fields("ICAO", "REGION", "NAME", "TYPE", "FREQUENCY", "VOR_LATITUDE", "VOR_LONGITUDE", "VOR_ALTITUDE",
+			"IS_NAV", "IS_DME", "HAS_GLIDE_SLOPE", "LOCALIZER", "GLIDE_SLOPE", "MAGVAR").
+		Close()
+}
@@ -0,0 +1,241 @@
+package facilities
+
+import "github.com/mycrew-online/sdk/pkg/types"
+
+// FieldKind describes how a facility field is encoded in the FACILITY_DATA stream
+type FieldKind uint8
+
+const (
+	KindInt32    FieldKind = iota + 1 // 4-byte signed integer
+	KindFloat32                       // 4-byte float
+	KindFloat64                       // 8-byte double
+	KindString8                       // char[8]
+	KindString32                      // char[32]
+	KindString64                      // char[64]
+)
+
+// Size returns the number of bytes a field of this kind occupies
+func (k FieldKind) Size() int {
+	switch k {
+	case KindInt32, KindFloat32:
+		return 4
+	case KindFloat64, KindString8:
+		return 8
+	case KindString32:
+		return 32
+	case KindString64:
+		return 64
+	default:
+		return 0
+	}
+}
+
+// scopeTypes maps the names accepted by "OPEN <name>" to the record type SimConnect reports.
+// Runway sub-structures share a record type, so several names resolve to PAVEMENT, VASI, etc.
+var scopeTypes = map[string]types.FacilityDataType{
+	"AIRPORT":                   types.SIMCONNECT_FACILITY_DATA_AIRPORT,
+	"RUNWAY":                    types.SIMCONNECT_FACILITY_DATA_RUNWAY,
+	"START":                     types.SIMCONNECT_FACILITY_DATA_START,
+	"FREQUENCY":                 types.SIMCONNECT_FACILITY_DATA_FREQUENCY,
+	"HELIPAD":                   types.SIMCONNECT_FACILITY_DATA_HELIPAD,
+	"APPROACH":                  types.SIMCONNECT_FACILITY_DATA_APPROACH,
+	"APPROACH_TRANSITION":       types.SIMCONNECT_FACILITY_DATA_APPROACH_TRANSITION,
+	"APPROACH_LEG":              types.SIMCONNECT_FACILITY_DATA_APPROACH_LEG,
+	"FINAL_APPROACH_LEG":        types.SIMCONNECT_FACILITY_DATA_FINAL_APPROACH_LEG,
+	"MISSED_APPROACH_LEG":       types.SIMCONNECT_FACILITY_DATA_MISSED_APPROACH_LEG,
+	"DEPARTURE":                 types.SIMCONNECT_FACILITY_DATA_DEPARTURE,
+	"ARRIVAL":                   types.SIMCONNECT_FACILITY_DATA_ARRIVAL,
+	"RUNWAY_TRANSITION":         types.SIMCONNECT_FACILITY_DATA_RUNWAY_TRANSITION,
+	"ENROUTE_TRANSITION":        types.SIMCONNECT_FACILITY_DATA_ENROUTE_TRANSITION,
+	"TAXI_POINT":                types.SIMCONNECT_FACILITY_DATA_TAXI_POINT,
+	"TAXI_PARKING":              types.SIMCONNECT_FACILITY_DATA_TAXI_PARKING,
+	"TAXI_PATH":                 types.SIMCONNECT_FACILITY_DATA_TAXI_PATH,
+	"TAXI_NAME":                 types.SIMCONNECT_FACILITY_DATA_TAXI_NAME,
+	"JETWAY":                    types.SIMCONNECT_FACILITY_DATA_JETWAY,
+	"VOR":                       types.SIMCONNECT_FACILITY_DATA_VOR,
+	"NDB":                       types.SIMCONNECT_FACILITY_DATA_NDB,
+	"WAYPOINT":                  types.SIMCONNECT_FACILITY_DATA_WAYPOINT,
+	"ROUTE":                     types.SIMCONNECT_FACILITY_DATA_ROUTE,
+	"PRIMARY_THRESHOLD":         types.SIMCONNECT_FACILITY_DATA_PAVEMENT,
+	"PRIMARY_BLASTPAD":          types.SIMCONNECT_FACILITY_DATA_PAVEMENT,
+	"PRIMARY_OVERRUN":           types.SIMCONNECT_FACILITY_DATA_PAVEMENT,
+	"SECONDARY_THRESHOLD":       types.SIMCONNECT_FACILITY_DATA_PAVEMENT,
+	"SECONDARY_BLASTPAD":        types.SIMCONNECT_FACILITY_DATA_PAVEMENT,
+	"SECONDARY_OVERRUN":         types.SIMCONNECT_FACILITY_DATA_PAVEMENT,
+	"PRIMARY_APPROACH_LIGHTS":   types.SIMCONNECT_FACILITY_DATA_APPROACH_LIGHTS,
+	"SECONDARY_APPROACH_LIGHTS": types.SIMCONNECT_FACILITY_DATA_APPROACH_LIGHTS,
+	"PRIMARY_LEFT_VASI":         types.SIMCONNECT_FACILITY_DATA_VASI,
+	"PRIMARY_RIGHT_VASI":        types.SIMCONNECT_FACILITY_DATA_VASI,
+	"SECONDARY_LEFT_VASI":       types.SIMCONNECT_FACILITY_DATA_VASI,
+	"SECONDARY_RIGHT_VASI":      types.SIMCONNECT_FACILITY_DATA_VASI,
+	"HOLDING_PATTERN":           types.SIMCONNECT_FACILITY_DATA_HOLDING_PATTERN,
+}
+
+// positionFields are shared by every scope that carries a location
+var positionFields = map[string]FieldKind{
+	"LATITUDE":  KindFloat64,
+	"LONGITUDE": KindFloat64,
+	"ALTITUDE":  KindFloat64,
+}
+
+// pavementFields covers thresholds, blastpads and overruns
+var pavementFields = map[string]FieldKind{
+	"LENGTH": KindFloat32,
+	"WIDTH":  KindFloat32,
+	"ENABLE": KindInt32,
+}
+
+// fieldCatalogue lists the documented fields per record type together with their encoding.
+// Fields not listed here can still be requested through Definition.FieldOfKind.
+var fieldCatalogue = map[types.FacilityDataType]map[string]FieldKind{
+	types.SIMCONNECT_FACILITY_DATA_AIRPORT: merge(positionFields, map[string]FieldKind{
+		"MAGVAR":              KindFloat32,
+		"NAME":                KindString32,
+		"NAME64":              KindString64,
+		"ICAO":                KindString8,
+		"REGION":              KindString8,
+		"TOWER_LATITUDE":      KindFloat64,
+		"TOWER_LONGITUDE":     KindFloat64,
+		"TOWER_ALTITUDE":      KindFloat64,
+		"TRANSITION_ALTITUDE": KindFloat32,
+		"TRANSITION_LEVEL":    KindFloat32,
+		"N_RUNWAYS":           KindInt32,
+		"N_STARTS":            KindInt32,
+		"N_FREQUENCIES":       KindInt32,
+		"N_HELIPADS":          KindInt32,
+		"N_APPROACHES":        KindInt32,
+		"N_DEPARTURES":        KindInt32,
+		"N_ARRIVALS":          KindInt32,
+		"N_TAXI_POINTS":       KindInt32,
+		"N_TAXI_PARKINGS":     KindInt32,
+		"N_TAXI_PATHS":        KindInt32,
+		"N_TAXI_NAMES":        KindInt32,
+		"N_JETWAYS":           KindInt32,
+	}),
+	types.SIMCONNECT_FACILITY_DATA_RUNWAY: merge(positionFields, map[string]FieldKind{
+		"HEADING":              KindFloat32,
+		"LENGTH":               KindFloat32,
+		"WIDTH":                KindFloat32,
+		"PATTERN_ALTITUDE":     KindFloat32,
+		"SLOPE":                KindFloat32,
+		"TRUE_SLOPE":           KindFloat32,
+		"SURFACE":              KindInt32,
+		"PRIMARY_ILS_ICAO":     KindString8,
+		"PRIMARY_ILS_REGION":   KindString8,
+		"PRIMARY_ILS_TYPE":     KindInt32,
+		"PRIMARY_NUMBER":       KindInt32,
+		"PRIMARY_DESIGNATOR":   KindInt32,
+		"SECONDARY_ILS_ICAO":   KindString8,
+		"SECONDARY_ILS_REGION": KindString8,
+		"SECONDARY_ILS_TYPE":   KindInt32,
+		"SECONDARY_NUMBER":     KindInt32,
+		"SECONDARY_DESIGNATOR": KindInt32,
+	}),
+	types.SIMCONNECT_FACILITY_DATA_PAVEMENT: pavementFields,
+	types.SIMCONNECT_FACILITY_DATA_START: merge(positionFields, map[string]FieldKind{
+		"HEADING":    KindFloat32,
+		"NUMBER":     KindInt32,
+		"DESIGNATOR": KindInt32,
+		"TYPE":       KindInt32,
+	}),
+	types.SIMCONNECT_FACILITY_DATA_FREQUENCY: {
+		"TYPE":      KindInt32,
+		"FREQUENCY": KindInt32,
+		"NAME":      KindString64,
+	},
+	types.SIMCONNECT_FACILITY_DATA_HELIPAD: merge(positionFields, map[string]FieldKind{
+		"HEADING": KindFloat32,
+		"LENGTH":  KindFloat32,
+		"WIDTH":   KindFloat32,
+		"SURFACE": KindInt32,
+		"TYPE":    KindInt32,
+	}),
+	types.SIMCONNECT_FACILITY_DATA_TAXI_PARKING: {
+		"TYPE":            KindInt32,
+		"TAXI_POINT_TYPE": KindInt32,
+		"NAME":            KindInt32,
+		"SUFFIX":          KindInt32,
+		"NUMBER":          KindInt32,
+		"ORIENTATION":     KindInt32,
+		"HEADING":         KindFloat32,
+		"RADIUS":          KindFloat32,
+		"BIAS_X":          KindFloat32,
+		"BIAS_Z":          KindFloat32,
+		"N_AIRLINES":      KindInt32,
+	},
+	types.SIMCONNECT_FACILITY_DATA_JETWAY: {
+		"PARKING_GATE":   KindInt32,
+		"PARKING_SUFFIX": KindInt32,
+		"PARKING_SPOT":   KindInt32,
+	},
+	types.SIMCONNECT_FACILITY_DATA_VOR: {
+		"VOR_LATITUDE":       KindFloat64,
+		"VOR_LONGITUDE":      KindFloat64,
+		"VOR_ALTITUDE":       KindFloat64,
+		"DME_LATITUDE":       KindFloat64,
+		"DME_LONGITUDE":      KindFloat64,
+		"DME_ALTITUDE":       KindFloat64,
+		"GS_LATITUDE":        KindFloat64,
+		"GS_LONGITUDE":       KindFloat64,
+		"GS_ALTITUDE":        KindFloat64,
+		"TACAN_LATITUDE":     KindFloat64,
+		"TACAN_LONGITUDE":    KindFloat64,
+		"TACAN_ALTITUDE":     KindFloat64,
+		"IS_NAV":             KindInt32,
+		"IS_DME":             KindInt32,
+		"IS_TACAN":           KindInt32,
+		"HAS_GLIDE_SLOPE":    KindInt32,
+		"DME_AT_NAV":         KindInt32,
+		"DME_AT_GLIDE_SLOPE": KindInt32,
+		"HAS_BACK_COURSE":    KindInt32,
+		"FREQUENCY":          KindInt32,
+		"TYPE":               KindInt32,
+		"NAV_RANGE":          KindFloat32,
+		"MAGVAR":             KindFloat32,
+		"ICAO":               KindString8,
+		"REGION":             KindString8,
+		"LOCALIZER":          KindFloat32,
+		"LOCALIZER_WIDTH":    KindFloat32,
+		"GLIDE_SLOPE":        KindFloat32,
+		"NAME":               KindString64,
+	},
+	types.SIMCONNECT_FACILITY_DATA_NDB: merge(positionFields, map[string]FieldKind{
+		"FREQUENCY":       KindInt32,
+		"TYPE":            KindInt32,
+		"RANGE":           KindFloat32,
+		"MAGVAR":          KindFloat32,
+		"ICAO":            KindString8,
+		"REGION":          KindString8,
+		"IS_TERMINAL_NDB": KindInt32,
+		"NAME":            KindString64,
+	}),
+	types.SIMCONNECT_FACILITY_DATA_WAYPOINT: merge(positionFields, map[string]FieldKind{
+		"TYPE":            KindInt32,
+		"MAGVAR":          KindFloat32,
+		"N_ROUTES":        KindInt32,
+		"ICAO":            KindString8,
+		"REGION":          KindString8,
+		"IS_TERMINAL_WPT": KindInt32,
+	}),
+}
+
+// lookupField returns the encoding of a documented field of the given record type
+func lookupField(t types.FacilityDataType, name string) (FieldKind, bool) {
+	fields, ok := fieldCatalogue[t]
+	if !ok {
+		return 0, false
+	}
+	kind, ok := fields[name]
+	return kind, ok
+}
+
+func merge(base, extra map[string]FieldKind) map[string]FieldKind {
+	out := make(map[string]FieldKind, len(base)+len(extra))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range extra {
+		out[k] = v
+	}
+	return out
+}
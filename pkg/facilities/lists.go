@@ -0,0 +1,207 @@
+package facilities
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/mycrew-online/sdk/internal/wire"
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// Packed sizes of the list records defined in SimConnect.h
+const (
+	airportRecordSize  = 6 + 3 + 3*8
+	waypointRecordSize = airportRecordSize + 4
+	ndbRecordSize      = waypointRecordSize + 4
+	vorRecordSize      = ndbRecordSize + 4 + 4 + 3*8 + 4
+	minimalRecordSize  = 1 + 9 + 3 + 5 + 3*8
+)
+
+// DecodeList decodes the records of one list page. recvID selects the record layout;
+// payload holds the bytes following the SIMCONNECT_RECV_LIST_TEMPLATE header.
+func DecodeList(recvID types.SimConnectRecvID, header types.FacilityListData, arraySize uint32, payload []byte) *types.FacilityListData {
+	page := header
+	if arraySize == 0 {
+		return &page
+	}
+
+	// Derive the stride from the payload so newer SDK revisions with longer records still decode
+	stride := len(payload) / int(arraySize)
+
+	for i := 0; i < int(arraySize); i++ {
+		if (i+1)*stride > len(payload) {
+			break
+		}
+		r := wire.NewReader(payload[i*stride : (i+1)*stride])
+
+		switch recvID {
+		case types.SIMCONNECT_RECV_ID_AIRPORT_LIST:
+			if stride < airportRecordSize {
+				return &page
+			}
+			page.ListType = types.SIMCONNECT_FACILITY_LIST_TYPE_AIRPORT
+			page.Airports = append(page.Airports, readAirport(r))
+		case types.SIMCONNECT_RECV_ID_WAYPOINT_LIST:
+			if stride < waypointRecordSize {
+				return &page
+			}
+			page.ListType = types.SIMCONNECT_FACILITY_LIST_TYPE_WAYPOINT
+			page.Waypoints = append(page.Waypoints, readWaypoint(r))
+		case types.SIMCONNECT_RECV_ID_NDB_LIST:
+			if stride < ndbRecordSize {
+				return &page
+			}
+			page.ListType = types.SIMCONNECT_FACILITY_LIST_TYPE_NDB
+			page.NDBs = append(page.NDBs, readNDB(r))
+		case types.SIMCONNECT_RECV_ID_VOR_LIST:
+			if stride < vorRecordSize {
+				return &page
+			}
+			page.ListType = types.SIMCONNECT_FACILITY_LIST_TYPE_VOR
+			page.VORs = append(page.VORs, readVOR(r))
+		case types.SIMCONNECT_RECV_ID_FACILITY_MINIMAL_LIST:
+			if stride < minimalRecordSize {
+				return &page
+			}
+			page.Minimal = append(page.Minimal, readMinimal(r))
+		}
+	}
+	return &page
+}
+
+func readAirport(r *wire.Reader) types.FacilityAirport {
+	return types.FacilityAirport{
+		Ident:     r.String(6),
+		Region:    r.String(3),
+		Latitude:  r.Float64(),
+		Longitude: r.Float64(),
+		Altitude:  r.Float64(),
+	}
+}
+
+func readWaypoint(r *wire.Reader) types.FacilityWaypoint {
+	return types.FacilityWaypoint{
+		FacilityAirport: readAirport(r),
+		MagVar:          r.Float32(),
+	}
+}
+
+func readNDB(r *wire.Reader) types.FacilityNDB {
+	return types.FacilityNDB{
+		FacilityWaypoint: readWaypoint(r),
+		Frequency:        r.Uint32(),
+	}
+}
+
+func readVOR(r *wire.Reader) types.FacilityVOR {
+	return types.FacilityVOR{
+		FacilityNDB:     readNDB(r),
+		Flags:           r.Uint32(),
+		Localizer:       r.Float32(),
+		GlideLat:        r.Float64(),
+		GlideLon:        r.Float64(),
+		GlideAlt:        r.Float64(),
+		GlideSlopeAngle: r.Float32(),
+	}
+}
+
+func readMinimal(r *wire.Reader) types.FacilityMinimal {
+	return types.FacilityMinimal{
+		ICAO: types.ICAO{
+			Type:    r.String(1),
+			Ident:   r.String(9),
+			Region:  r.String(3),
+			Airport: r.String(5),
+		},
+		LLA: types.LatLonAlt{
+			Latitude:  r.Float64(),
+			Longitude: r.Float64(),
+			Altitude:  r.Float64(),
+		},
+	}
+}
+
+// DistanceNM returns the great-circle distance between two positions in nautical miles
+func DistanceNM(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadiusNM = 3440.065
+	toRad := math.Pi / 180
+	dLat := (lat2 - lat1) * toRad
+	dLon := (lon2 - lon1) * toRad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*toRad)*math.Cos(lat2*toRad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusNM * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// NearestAirports returns up to n airports sorted by distance from the given position
+func NearestAirports(airports []types.FacilityAirport, lat, lon float64, n int) []types.FacilityAirport {
+	sorted := make([]types.FacilityAirport, len(airports))
+	copy(sorted, airports)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return DistanceNM(lat, lon, sorted[i].Latitude, sorted[i].Longitude) <
+			DistanceNM(lat, lon, sorted[j].Latitude, sorted[j].Longitude)
+	})
+	if n >= 0 && n < len(sorted) {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// AirportTracker keeps the set of airports inside the reality bubble up to date from a
+// SubscribeToFacilities_EX1 subscription, for "nearest airports" style displays.
+// It is safe for concurrent use.
+type AirportTracker struct {
+	mu                sync.RWMutex
+	inRangeRequestID  uint32
+	outRangeRequestID uint32
+	airports          map[string]types.FacilityAirport
+}
+
+// NewAirportTracker creates a tracker for the request IDs passed to SubscribeToFacilities_EX1
+func NewAirportTracker(inRangeRequestID, outRangeRequestID uint32) *AirportTracker {
+	return &AirportTracker{
+		inRangeRequestID:  inRangeRequestID,
+		outRangeRequestID: outRangeRequestID,
+		airports:          make(map[string]types.FacilityAirport),
+	}
+}
+
+// Apply adds or removes the airports of a list page depending on its request ID.
+// It returns false when the page does not belong to this tracker.
+func (t *AirportTracker) Apply(page *types.FacilityListData) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch page.RequestID {
+	case t.inRangeRequestID:
+		for _, a := range page.Airports {
+			t.airports[a.Region+a.Ident] = a
+		}
+	case t.outRangeRequestID:
+		for _, a := range page.Airports {
+			delete(t.airports, a.Region+a.Ident)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// Len returns the number of airports currently in range
+func (t *AirportTracker) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.airports)
+}
+
+// Nearest returns up to n in-range airports sorted by distance from the given position
+func (t *AirportTracker) Nearest(lat, lon float64, n int) []types.FacilityAirport {
+	t.mu.RLock()
+	all := make([]types.FacilityAirport, 0, len(t.airports))
+	for _, a := range t.airports {
+		all = append(all, a)
+	}
+	t.mu.RUnlock()
+
+	return NearestAirports(all, lat, lon, n)
+}
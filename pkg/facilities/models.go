@@ -0,0 +1,354 @@
+package facilities
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// RunwayDesignator is the letter suffix of a runway end (L, R, C, ...)
+type RunwayDesignator int32
+
+const (
+	RunwayDesignatorNone RunwayDesignator = iota
+	RunwayDesignatorLeft
+	RunwayDesignatorRight
+	RunwayDesignatorCenter
+	RunwayDesignatorWater
+	RunwayDesignatorA
+	RunwayDesignatorB
+)
+
+// Suffix returns the designator letter used in runway names
+func (d RunwayDesignator) Suffix() string {
+	switch d {
+	case RunwayDesignatorLeft:
+		return "L"
+	case RunwayDesignatorRight:
+		return "R"
+	case RunwayDesignatorCenter:
+		return "C"
+	case RunwayDesignatorWater:
+		return "W"
+	case RunwayDesignatorA:
+		return "A"
+	case RunwayDesignatorB:
+		return "B"
+	default:
+		return ""
+	}
+}
+
+// FrequencyType classifies airport frequencies
+type FrequencyType int32
+
+const (
+	FrequencyNone FrequencyType = iota
+	FrequencyATIS
+	FrequencyMulticom
+	FrequencyUnicom
+	FrequencyCTAF
+	FrequencyGround
+	FrequencyTower
+	FrequencyClearance
+	FrequencyApproach
+	FrequencyDeparture
+	FrequencyCenter
+	FrequencyFSS
+	FrequencyAWOS
+	FrequencyASOS
+	FrequencyClearancePreTaxi
+	FrequencyRemoteClearanceDelivery
+)
+
+var frequencyTypeNames = []string{
+	"NONE", "ATIS", "MULTICOM", "UNICOM", "CTAF", "GROUND", "TOWER", "CLEARANCE", "APPROACH",
+	"DEPARTURE", "CENTER", "FSS", "AWOS", "ASOS", "CLEARANCE_PRE_TAXI", "REMOTE_CLEARANCE_DELIVERY",
+}
+
+// String returns the SimConnect name of the frequency type
+func (t FrequencyType) String() string {
+	if t >= 0 && int(t) < len(frequencyTypeNames) {
+		return frequencyTypeNames[t]
+	}
+	return "UNKNOWN"
+}
+
+// ParkingType classifies taxi parking spots
+type ParkingType int32
+
+const (
+	ParkingNone ParkingType = iota
+	ParkingRampGA
+	ParkingRampGASmall
+	ParkingRampGAMedium
+	ParkingRampGALarge
+	ParkingRampCargo
+	ParkingRampMilCargo
+	ParkingRampMilCombat
+	ParkingGateSmall
+	ParkingGateMedium
+	ParkingGateHeavy
+	ParkingDockGA
+	ParkingFuel
+	ParkingVehicles
+	ParkingRampGAExtra
+	ParkingGateExtra
+)
+
+// IsGate reports whether the parking spot is a gate
+func (t ParkingType) IsGate() bool {
+	switch t {
+	case ParkingGateSmall, ParkingGateMedium, ParkingGateHeavy, ParkingGateExtra:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParkingName is the name prefix of a parking spot (PARKING, GATE, GATE_A, ...)
+type ParkingName int32
+
+const (
+	ParkingNameNone ParkingName = iota
+	ParkingNameParking
+	ParkingNameNParking
+	ParkingNameNEParking
+	ParkingNameEParking
+	ParkingNameSEParking
+	ParkingNameSParking
+	ParkingNameSWParking
+	ParkingNameWParking
+	ParkingNameNWParking
+	ParkingNameGate
+	ParkingNameDock
+	ParkingNameGateA // GATE_A .. GATE_Z follow consecutively
+)
+
+// String returns a human readable prefix, e.g. "GATE B"
+func (n ParkingName) String() string {
+	switch {
+	case n == ParkingNameNone:
+		return ""
+	case n >= ParkingNameGateA && n < ParkingNameGateA+26:
+		return "GATE " + string(rune('A'+int(n-ParkingNameGateA)))
+	}
+	names := []string{"", "PARKING", "N PARKING", "NE PARKING", "E PARKING", "SE PARKING",
+		"S PARKING", "SW PARKING", "W PARKING", "NW PARKING", "GATE", "DOCK"}
+	if n > 0 && int(n) < len(names) {
+		return names[n]
+	}
+	return "UNKNOWN"
+}
+
+// Airport is the typed form of an AIRPORT record and its children
+type Airport struct {
+	ICAO               string      `json:"icao"`
+	Region             string      `json:"region"`
+	Name               string      `json:"name"`
+	Latitude           float64     `json:"latitude"`
+	Longitude          float64     `json:"longitude"`
+	Altitude           float64     `json:"altitude"` // Meters
+	MagVar             float64     `json:"magvar"`
+	TransitionAltitude float64     `json:"transition_altitude"`
+	Runways            []Runway    `json:"runways,omitempty"`
+	Frequencies        []Frequency `json:"frequencies,omitempty"`
+	Parkings           []Parking   `json:"parkings,omitempty"`
+}
+
+// Runway is the typed form of a RUNWAY record
+type Runway struct {
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+	Altitude  float64   `json:"altitude"`
+	Heading   float64   `json:"heading"` // True heading of the primary end
+	Length    float64   `json:"length"`  // Meters
+	Width     float64   `json:"width"`   // Meters
+	Surface   int32     `json:"surface"`
+	Primary   RunwayEnd `json:"primary"`
+	Secondary RunwayEnd `json:"secondary"`
+}
+
+// RunwayEnd describes one end of a runway
+type RunwayEnd struct {
+	Name            string           `json:"name"` // e.g. "16R"
+	Number          int32            `json:"number"`
+	Designator      RunwayDesignator `json:"designator"`
+	Heading         float64          `json:"heading"` // True heading when landing on this end
+	ILSICAO         string           `json:"ils_icao,omitempty"`
+	ILSRegion       string           `json:"ils_region,omitempty"`
+	ILSType         int32            `json:"ils_type,omitempty"`
+	ThresholdLength float64          `json:"threshold_length,omitempty"` // Displaced threshold, meters
+}
+
+// Frequency is the typed form of a FREQUENCY record
+type Frequency struct {
+	Type FrequencyType `json:"type"`
+	MHz  float64       `json:"mhz"`
+	Name string        `json:"name"`
+}
+
+// Parking is the typed form of a TAXI_PARKING record
+type Parking struct {
+	Type    ParkingType `json:"type"`
+	Name    ParkingName `json:"name"`
+	Number  int32       `json:"number"`
+	Suffix  int32       `json:"suffix"`
+	Heading float64     `json:"heading"`
+	Radius  float64     `json:"radius"` // Meters
+	BiasX   float64     `json:"bias_x"` // Offset from the airport reference point, meters
+	BiasZ   float64     `json:"bias_z"`
+}
+
+// Label returns the name shown to users, e.g. "GATE B 12"
+func (p Parking) Label() string {
+	return strings.TrimSpace(fmt.Sprintf("%s %d", p.Name, p.Number))
+}
+
+// Navaid is the typed form of a VOR record (VOR, ILS/localizer, DME)
+type Navaid struct {
+	ICAO          string  `json:"icao"`
+	Region        string  `json:"region"`
+	Name          string  `json:"name"`
+	Type          int32   `json:"type"`
+	MHz           float64 `json:"mhz"`
+	Latitude      float64 `json:"latitude"`
+	Longitude     float64 `json:"longitude"`
+	Altitude      float64 `json:"altitude"`
+	HasGlideSlope bool    `json:"has_glide_slope"`
+	Localizer     float64 `json:"localizer,omitempty"` // Localizer course, degrees true
+	GlideSlope    float64 `json:"glide_slope,omitempty"`
+	MagVar        float64 `json:"magvar"`
+}
+
+// Airport converts an AIRPORT node into its typed form
+func (n *Node) Airport() (*Airport, error) {
+	if n == nil || n.Type != types.SIMCONNECT_FACILITY_DATA_AIRPORT {
+		return nil, fmt.Errorf("node is not an AIRPORT record")
+	}
+	airport := &Airport{
+		ICAO:               n.String("ICAO"),
+		Region:             n.String("REGION"),
+		Name:               firstNonEmpty(n.String("NAME64"), n.String("NAME")),
+		Latitude:           n.Float("LATITUDE"),
+		Longitude:          n.Float("LONGITUDE"),
+		Altitude:           n.Float("ALTITUDE"),
+		MagVar:             n.Float("MAGVAR"),
+		TransitionAltitude: n.Float("TRANSITION_ALTITUDE"),
+	}
+	for _, c := range n.Children {
+		switch c.Type {
+		case types.SIMCONNECT_FACILITY_DATA_RUNWAY:
+			airport.Runways = append(airport.Runways, c.runway())
+		case types.SIMCONNECT_FACILITY_DATA_FREQUENCY:
+			airport.Frequencies = append(airport.Frequencies, Frequency{
+				Type: FrequencyType(c.Int("TYPE")),
+				MHz:  float64(c.Int("FREQUENCY")) / 1e6,
+				Name: c.String("NAME"),
+			})
+		case types.SIMCONNECT_FACILITY_DATA_TAXI_PARKING:
+			airport.Parkings = append(airport.Parkings, Parking{
+				Type:    ParkingType(c.Int("TYPE")),
+				Name:    ParkingName(c.Int("NAME")),
+				Number:  c.Int("NUMBER"),
+				Suffix:  c.Int("SUFFIX"),
+				Heading: c.Float("HEADING"),
+				Radius:  c.Float("RADIUS"),
+				BiasX:   c.Float("BIAS_X"),
+				BiasZ:   c.Float("BIAS_Z"),
+			})
+		}
+	}
+	return airport, nil
+}
+
+// runway converts a RUNWAY node, including its threshold children
+func (n *Node) runway() Runway {
+	heading := n.Float("HEADING")
+	rw := Runway{
+		Latitude:  n.Float("LATITUDE"),
+		Longitude: n.Float("LONGITUDE"),
+		Altitude:  n.Float("ALTITUDE"),
+		Heading:   heading,
+		Length:    n.Float("LENGTH"),
+		Width:     n.Float("WIDTH"),
+		Surface:   n.Int("SURFACE"),
+		Primary:   n.runwayEnd("PRIMARY", heading),
+		Secondary: n.runwayEnd("SECONDARY", normalizeHeading(heading+180)),
+	}
+	if t := n.Child("PRIMARY_THRESHOLD"); t != nil {
+		rw.Primary.ThresholdLength = t.Float("LENGTH")
+	}
+	if t := n.Child("SECONDARY_THRESHOLD"); t != nil {
+		rw.Secondary.ThresholdLength = t.Float("LENGTH")
+	}
+	return rw
+}
+
+func (n *Node) runwayEnd(prefix string, heading float64) RunwayEnd {
+	end := RunwayEnd{
+		Number:     n.Int(prefix + "_NUMBER"),
+		Designator: RunwayDesignator(n.Int(prefix + "_DESIGNATOR")),
+		Heading:    heading,
+		ILSICAO:    n.String(prefix + "_ILS_ICAO"),
+		ILSRegion:  n.String(prefix + "_ILS_REGION"),
+		ILSType:    n.Int(prefix + "_ILS_TYPE"),
+	}
+	end.Name = RunwayName(end.Number, end.Designator)
+	return end
+}
+
+// Navaid converts a VOR node into its typed form
+func (n *Node) Navaid() (*Navaid, error) {
+	if n == nil || n.Type != types.SIMCONNECT_FACILITY_DATA_VOR {
+		return nil, fmt.Errorf("node is not a VOR record")
+	}
+	return &Navaid{
+		ICAO:          n.String("ICAO"),
+		Region:        n.String("REGION"),
+		Name:          n.String("NAME"),
+		Type:          n.Int("TYPE"),
+		MHz:           float64(n.Int("FREQUENCY")) / 1e6,
+		Latitude:      n.Float("VOR_LATITUDE"),
+		Longitude:     n.Float("VOR_LONGITUDE"),
+		Altitude:      n.Float("VOR_ALTITUDE"),
+		HasGlideSlope: n.Int("HAS_GLIDE_SLOPE") != 0,
+		Localizer:     n.Float("LOCALIZER"),
+		GlideSlope:    n.Float("GLIDE_SLOPE"),
+		MagVar:        n.Float("MAGVAR"),
+	}, nil
+}
+
+// RunwayName formats a runway end identifier such as "04L" or "N" for lettered water runways
+func RunwayName(number int32, designator RunwayDesignator) string {
+	var base string
+	switch {
+	case number >= 1 && number <= 36:
+		base = fmt.Sprintf("%02d", number)
+	case number >= 37 && number <= 44:
+		base = []string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}[number-37]
+	default:
+		base = ""
+	}
+	return base + designator.Suffix()
+}
+
+func normalizeHeading(h float64) float64 {
+	for h >= 360 {
+		h -= 360
+	}
+	for h < 0 {
+		h += 360
+	}
+	return h
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+package flightplan
+
+import "github.com/mycrew-online/sdk/pkg/types"
+
+// TargetAltitude returns the altitude to fly at the waypoint: the constraint when present, else its position altitude
+func (w Waypoint) TargetAltitude() float64 {
+	switch w.AltitudeDescriptor {
+	case AltitudeAt, AltitudeAtOrAbove, AltitudeAtOrBelow:
+		return w.Altitude1
+	case AltitudeBetween:
+		return (w.Altitude1 + w.Altitude2) / 2
+	}
+	return w.Position.Altitude
+}
+
+// LatLonAlt converts the waypoint to SIMCONNECT_DATA_LATLONALT
+func (w Waypoint) LatLonAlt() types.LatLonAlt {
+	return w.Position.LatLonAlt()
+}
+
+// LatLonAlts returns the positions of all waypoints
+func (p *Plan) LatLonAlts() []types.LatLonAlt {
+	out := make([]types.LatLonAlt, len(p.Waypoints))
+	for i, w := range p.Waypoints {
+		out[i] = w.LatLonAlt()
+	}
+	return out
+}
+
+// SimWaypoints converts the plan into SIMCONNECT_DATA_WAYPOINT entries for an AI object.
+// En-route waypoints use their altitude constraint, falling back to the cruising altitude;
+// airports stay at field elevation and are flagged ON_GROUND. A positive speed is requested on every waypoint.
+func (p *Plan) SimWaypoints(speedKnots float64) []types.Waypoint {
+	out := make([]types.Waypoint, len(p.Waypoints))
+	for i, w := range p.Waypoints {
+		wp := types.Waypoint{
+			Latitude:  w.Position.Latitude,
+			Longitude: w.Position.Longitude,
+			Altitude:  w.TargetAltitude(),
+			Flags:     types.SIMCONNECT_WAYPOINT_COMPUTE_VERTICAL_SPEED,
+		}
+		switch {
+		case w.Type == WaypointAirport:
+			wp.Altitude = w.Position.Altitude
+			wp.Flags |= types.SIMCONNECT_WAYPOINT_ON_GROUND
+		case w.AltitudeDescriptor == "" && p.CruisingAltitude > 0:
+			wp.Altitude = p.CruisingAltitude
+		}
+		if speedKnots > 0 {
+			wp.Speed = speedKnots
+			wp.Flags |= types.SIMCONNECT_WAYPOINT_SPEED_REQUESTED
+		}
+		out[i] = wp
+	}
+	return out
+}
@@ -0,0 +1,234 @@
+// Package flightplan reads, writes and validates MSFS flight plan (.PLN) files
+// and converts their waypoints into SimConnect structures.
+package flightplan
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// FlightType is the FPType of a plan
+type FlightType string
+
+const (
+	FlightTypeIFR FlightType = "IFR"
+	FlightTypeVFR FlightType = "VFR"
+)
+
+// RouteType is the RouteType of a plan
+type RouteType string
+
+const (
+	RouteDirect  RouteType = "Direct"
+	RouteVOR     RouteType = "VOR"
+	RouteLowAlt  RouteType = "LowAlt"
+	RouteHighAlt RouteType = "HighAlt"
+)
+
+// WaypointType is the ATCWaypointType of a waypoint
+type WaypointType string
+
+const (
+	WaypointAirport      WaypointType = "Airport"
+	WaypointIntersection WaypointType = "Intersection"
+	WaypointVOR          WaypointType = "VOR"
+	WaypointNDB          WaypointType = "NDB"
+	WaypointUser         WaypointType = "User"
+)
+
+// AltitudeDescriptor is the kind of altitude constraint on a waypoint
+type AltitudeDescriptor string
+
+const (
+	AltitudeAt        AltitudeDescriptor = "AT"
+	AltitudeAtOrAbove AltitudeDescriptor = "AT_OR_ABOVE"
+	AltitudeAtOrBelow AltitudeDescriptor = "AT_OR_BELOW"
+	AltitudeBetween   AltitudeDescriptor = "BETWEEN" // Alt1 is the upper, Alt2 the lower limit
+)
+
+// Plan is the FlightPlan.FlightPlan element of a .PLN file
+type Plan struct {
+	Title            string      `xml:"Title"`
+	Type             FlightType  `xml:"FPType"`
+	RouteType        RouteType   `xml:"RouteType,omitempty"`
+	CruisingAltitude float64     `xml:"CruisingAlt"` // Feet
+	DepartureID      string      `xml:"DepartureID"`
+	DepartureLLA     *Position   `xml:"DepartureLLA,omitempty"`
+	DestinationID    string      `xml:"DestinationID"`
+	DestinationLLA   *Position   `xml:"DestinationLLA,omitempty"`
+	Description      string      `xml:"Descr,omitempty"`
+	DeparturePos     string      `xml:"DeparturePosition,omitempty"` // Runway or parking spot
+	DepartureName    string      `xml:"DepartureName,omitempty"`
+	DestinationName  string      `xml:"DestinationName,omitempty"`
+	AppVersion       *AppVersion `xml:"AppVersion,omitempty"`
+	Waypoints        []Waypoint  `xml:"ATCWaypoint"`
+}
+
+// AppVersion records the simulator build that wrote the plan
+type AppVersion struct {
+	Major int `xml:"AppVersionMajor"`
+	Build int `xml:"AppVersionBuild"`
+}
+
+// Waypoint is an ATCWaypoint element
+type Waypoint struct {
+	ID                 string             `xml:"id,attr"`
+	Type               WaypointType       `xml:"ATCWaypointType"`
+	Position           Position           `xml:"WorldPosition"`
+	SpeedMax           int                `xml:"SpeedMaxFP,omitempty"` // Knots; the simulator writes -1 for none
+	Airway             string             `xml:"ATCAirway,omitempty"`
+	Departure          string             `xml:"DepartureFP,omitempty"` // SID
+	Arrival            string             `xml:"ArrivalFP,omitempty"`   // STAR
+	ApproachType       string             `xml:"ApproachTypeFP,omitempty"`
+	ApproachSuffix     string             `xml:"SuffixFP,omitempty"`
+	RunwayNumber       string             `xml:"RunwayNumberFP,omitempty"`
+	RunwayDesignator   string             `xml:"RunwayDesignatorFP,omitempty"`
+	AltitudeDescriptor AltitudeDescriptor `xml:"AltDescFP,omitempty"`
+	Altitude1          float64            `xml:"Alt1FP,omitempty"` // Feet
+	Altitude2          float64            `xml:"Alt2FP,omitempty"` // Feet, BETWEEN only
+	ICAO               *ICAO              `xml:"ICAO,omitempty"`
+}
+
+// ICAO identifies the facility a waypoint refers to
+type ICAO struct {
+	Region  string `xml:"ICAORegion,omitempty"`
+	Ident   string `xml:"ICAOIdent"`
+	Airport string `xml:"ICAOAirport,omitempty"` // Owning airport of terminal waypoints
+}
+
+// document is the SimBase.Document root element
+type document struct {
+	XMLName xml.Name `xml:"SimBase.Document"`
+	Type    string   `xml:"Type,attr"`
+	Version string   `xml:"version,attr"`
+	Descr   string   `xml:"Descr"`
+	Plan    *Plan    `xml:"FlightPlan.FlightPlan"`
+}
+
+// New creates a plan from departure to destination airport waypoints
+func New(title string, flightType FlightType, departure, destination Waypoint) *Plan {
+	dep := departure.Position
+	dst := destination.Position
+	return &Plan{
+		Title:          title,
+		Type:           flightType,
+		RouteType:      RouteDirect,
+		DepartureID:    departure.ID,
+		DepartureLLA:   &dep,
+		DestinationID:  destination.ID,
+		DestinationLLA: &dst,
+		Description:    departure.ID + ", " + destination.ID,
+		Waypoints:      []Waypoint{departure, destination},
+	}
+}
+
+// Airport creates an airport waypoint
+func Airport(icao string, pos Position) Waypoint {
+	return Waypoint{
+		ID:       icao,
+		Type:     WaypointAirport,
+		Position: pos,
+		ICAO:     &ICAO{Ident: icao},
+	}
+}
+
+// Fix creates an intersection, VOR or NDB waypoint
+func Fix(ident, region string, t WaypointType, pos Position) Waypoint {
+	return Waypoint{
+		ID:       ident,
+		Type:     t,
+		Position: pos,
+		ICAO:     &ICAO{Region: region, Ident: ident},
+	}
+}
+
+// Insert adds en-route waypoints before the destination
+func (p *Plan) Insert(waypoints ...Waypoint) {
+	if len(p.Waypoints) == 0 {
+		p.Waypoints = append(p.Waypoints, waypoints...)
+		return
+	}
+	last := p.Waypoints[len(p.Waypoints)-1]
+	p.Waypoints = append(p.Waypoints[:len(p.Waypoints)-1], waypoints...)
+	p.Waypoints = append(p.Waypoints, last)
+}
+
+// Parse reads a .PLN document
+func Parse(r io.Reader) (*Plan, error) {
+	dec := xml.NewDecoder(r)
+	dec.CharsetReader = charsetReader
+
+	var doc document
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse flight plan: %w", err)
+	}
+	if doc.Plan == nil {
+		return nil, fmt.Errorf("failed to parse flight plan: no FlightPlan.FlightPlan element")
+	}
+	return doc.Plan, nil
+}
+
+// ParseFile reads a .PLN file
+func ParseFile(path string) (*Plan, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Write encodes the plan as a .PLN document
+func (p *Plan) Write(w io.Writer) error {
+	doc := document{
+		Type:    "AceXML",
+		Version: "1,0",
+		Descr:   "AceXML Document",
+		Plan:    p,
+	}
+
+	if _, err := io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "    ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write flight plan: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteFile writes the plan to path
+func (p *Plan) WriteFile(path string) error {
+	var buf bytes.Buffer
+	if err := p.Write(&buf); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// charsetReader accepts the encodings found in simulator-written plans
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "utf-8", "utf8", "":
+		return input, nil
+	case "iso-8859-1", "latin1", "windows-1252":
+		data, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		// Latin-1 bytes map directly to the first 256 code points
+		out := make([]byte, 0, len(data))
+		for _, b := range data {
+			out = utf8.AppendRune(out, rune(b))
+		}
+		return bytes.NewReader(out), nil
+	}
+	return nil, fmt.Errorf("unsupported flight plan encoding %q", charset)
+}
@@ -0,0 +1,106 @@
+package flightplan
+
+import (
+	"bytes"
+	"math"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func parseSample(t *testing.T) *Plan {
+	t.Helper()
+	plan, err := ParseFile(filepath.Join("testdata", "KSEA-KPDX.pln"))
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	return plan
+}
+
+func TestParseSample(t *testing.T) {
+	plan := parseSample(t)
+
+	if plan.Title != "KSEA to KPDX" || plan.Type != FlightTypeIFR || plan.RouteType != RouteHighAlt {
+		t.Errorf("header = %q %q %q", plan.Title, plan.Type, plan.RouteType)
+	}
+	if plan.CruisingAltitude != 24000 {
+		t.Errorf("CruisingAltitude = %v, want 24000", plan.CruisingAltitude)
+	}
+	if plan.AppVersion == nil || plan.AppVersion.Build != 282174 {
+		t.Errorf("AppVersion = %+v", plan.AppVersion)
+	}
+	if len(plan.Waypoints) != 4 {
+		t.Fatalf("len(Waypoints) = %d, want 4", len(plan.Waypoints))
+	}
+
+	dep := plan.DepartureLLA
+	if dep == nil || math.Abs(dep.Latitude-(47+26.0/60+56.0/3600)) > 1e-9 || math.Abs(dep.Longitude+(122+18.0/60+34.0/3600)) > 1e-9 || dep.Altitude != 433 {
+		t.Errorf("DepartureLLA = %+v", dep)
+	}
+
+	olm := plan.Waypoints[2]
+	if olm.Type != WaypointVOR || olm.Airway != "J70" || olm.AltitudeDescriptor != AltitudeBetween || olm.Altitude2 != 18000 {
+		t.Errorf("OLM = %+v", olm)
+	}
+	if olm.ICAO == nil || olm.ICAO.Region != "K1" {
+		t.Errorf("OLM ICAO = %+v", olm.ICAO)
+	}
+}
+
+func TestWriteRoundtrip(t *testing.T) {
+	plan := parseSample(t)
+
+	var buf bytes.Buffer
+	if err := plan.Write(&buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	reparsed, err := Parse(&buf)
+	if err != nil {
+		t.Fatalf("Parse written plan: %v", err)
+	}
+	if !reflect.DeepEqual(plan, reparsed) {
+		t.Errorf("roundtrip changed the plan\nbefore: %+v\nafter:  %+v", plan, reparsed)
+	}
+}
+
+func TestParsePosition(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Position
+		wantErr bool
+	}{
+		{`N47° 26' 56.00",W122° 18' 34.00",+000433.00`, Position{47.448889, -122.309444, 433}, false},
+		{`S33° 56' 46.00",E151° 10' 38.00"`, Position{-33.946111, 151.177222, 0}, false},
+		{`N47° 26' 56.00"`, Position{}, true},
+		{`X47° 26' 56.00",W122° 18' 34.00"`, Position{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePosition(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePosition(%q) err = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if math.Abs(got.Latitude-tt.want.Latitude) > 1e-6 || math.Abs(got.Longitude-tt.want.Longitude) > 1e-6 || got.Altitude != tt.want.Altitude {
+			t.Errorf("ParsePosition(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNewInsert(t *testing.T) {
+	dep := Airport("KSEA", Position{Latitude: 47.449, Longitude: -122.309, Altitude: 433})
+	dst := Airport("KPDX", Position{Latitude: 45.589, Longitude: -122.598, Altitude: 31})
+	plan := New("KSEA to KPDX", FlightTypeVFR, dep, dst)
+	plan.Insert(Fix("OLM", "K1", WaypointVOR, Position{Latitude: 46.972, Longitude: -122.902}))
+
+	var ids []string
+	for _, w := range plan.Waypoints {
+		ids = append(ids, w.ID)
+	}
+	if got := strings.Join(ids, ","); got != "KSEA,OLM,KPDX" {
+		t.Errorf("waypoints = %s, want KSEA,OLM,KPDX", got)
+	}
+	if err := plan.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package flightplan
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// Position is a point in the .PLN WorldPosition format:
+// N47° 26' 56.00",W122° 18' 34.00",+000433.00 (altitude in feet)
+type Position struct {
+	Latitude  float64 // Degrees, north positive
+	Longitude float64 // Degrees, east positive
+	Altitude  float64 // Feet
+}
+
+// ParsePosition parses a WorldPosition / DepartureLLA value
+func ParsePosition(s string) (Position, error) {
+	parts := strings.Split(strings.TrimSpace(s), ",")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Position{}, fmt.Errorf("invalid position %q: expected lat,lon[,alt]", s)
+	}
+
+	lat, err := parseAngle(parts[0], 'N', 'S')
+	if err != nil {
+		return Position{}, fmt.Errorf("invalid latitude in %q: %w", s, err)
+	}
+	lon, err := parseAngle(parts[1], 'E', 'W')
+	if err != nil {
+		return Position{}, fmt.Errorf("invalid longitude in %q: %w", s, err)
+	}
+
+	pos := Position{Latitude: lat, Longitude: lon}
+	if len(parts) == 3 {
+		alt, err := strconv.ParseFloat(strings.TrimSpace(parts[2]), 64)
+		if err != nil {
+			return Position{}, fmt.Errorf("invalid altitude in %q: %w", s, err)
+		}
+		pos.Altitude = alt
+	}
+	return pos, nil
+}
+
+// String formats the position the way the simulator writes it
+func (p Position) String() string {
+	return fmt.Sprintf("%s,%s,%+010.2f", formatAngle(p.Latitude, 'N', 'S'), formatAngle(p.Longitude, 'E', 'W'), p.Altitude)
+}
+
+// MarshalText implements encoding.TextMarshaler for XML encoding
+func (p Position) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for XML decoding
+func (p *Position) UnmarshalText(text []byte) error {
+	pos, err := ParsePosition(string(text))
+	if err != nil {
+		return err
+	}
+	*p = pos
+	return nil
+}
+
+// LatLonAlt converts the position to the SimConnect structure
+func (p Position) LatLonAlt() types.LatLonAlt {
+	return types.LatLonAlt{Latitude: p.Latitude, Longitude: p.Longitude, Altitude: p.Altitude}
+}
+
+// parseAngle parses H12° 34' 56.78" where H is the positive or negative hemisphere letter
+func parseAngle(s string, positive, negative byte) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty angle")
+	}
+
+	sign := 1.0
+	switch s[0] {
+	case positive:
+	case negative:
+		sign = -1
+	default:
+		return 0, fmt.Errorf("expected %c or %c hemisphere", positive, negative)
+	}
+
+	// Degrees, minutes and seconds are separated by their unit marks
+	fields := strings.FieldsFunc(s[1:], func(r rune) bool {
+		return r == '°' || r == '\'' || r == '"' || r == ' '
+	})
+	if len(fields) == 0 || len(fields) > 3 {
+		return 0, fmt.Errorf("malformed angle %q", s)
+	}
+
+	value := 0.0
+	scale := 1.0
+	for _, f := range fields {
+		v, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return 0, fmt.Errorf("malformed angle %q", s)
+		}
+		value += v / scale
+		scale *= 60
+	}
+	return sign * value, nil
+}
+
+// formatAngle formats a signed angle as H12° 34' 56.78"
+func formatAngle(v float64, positive, negative byte) string {
+	hemisphere := positive
+	if v < 0 {
+		hemisphere = negative
+		v = -v
+	}
+
+	// Work in hundredths of a second so rounding carries into minutes and degrees
+	total := int64(math.Round(v * 3600 * 100))
+	deg := total / (3600 * 100)
+	min := total / (60 * 100) % 60
+	sec := float64(total%(60*100)) / 100
+
+	return fmt.Sprintf("%c%d° %d' %.2f\"", hemisphere, deg, min, sec)
+}
@@ -0,0 +1,88 @@
+package flightplan
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationError lists every problem found in a plan
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid flight plan: " + strings.Join(e.Problems, "; ")
+}
+
+// Validate checks that the plan can be loaded by the simulator
+func (p *Plan) Validate() error {
+	var problems []string
+	add := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	switch p.Type {
+	case FlightTypeIFR, FlightTypeVFR:
+	default:
+		add("FPType must be IFR or VFR, got %q", p.Type)
+	}
+	if p.CruisingAltitude < 0 {
+		add("CruisingAlt must not be negative")
+	}
+	if strings.TrimSpace(p.DepartureID) == "" {
+		add("DepartureID is missing")
+	}
+	if strings.TrimSpace(p.DestinationID) == "" {
+		add("DestinationID is missing")
+	}
+	if len(p.Waypoints) < 2 {
+		add("a plan needs at least two waypoints, got %d", len(p.Waypoints))
+	} else {
+		if first := p.Waypoints[0]; first.Type == WaypointAirport && !strings.EqualFold(first.ID, p.DepartureID) {
+			add("first waypoint %s does not match DepartureID %s", first.ID, p.DepartureID)
+		}
+		if last := p.Waypoints[len(p.Waypoints)-1]; last.Type == WaypointAirport && !strings.EqualFold(last.ID, p.DestinationID) {
+			add("last waypoint %s does not match DestinationID %s", last.ID, p.DestinationID)
+		}
+	}
+
+	for i, w := range p.Waypoints {
+		name := fmt.Sprintf("waypoint %d (%s)", i, w.ID)
+		if strings.TrimSpace(w.ID) == "" {
+			add("waypoint %d has no id", i)
+		}
+		switch w.Type {
+		case WaypointAirport, WaypointIntersection, WaypointVOR, WaypointNDB, WaypointUser:
+		default:
+			add("%s has unknown type %q", name, w.Type)
+		}
+		if w.Position.Latitude < -90 || w.Position.Latitude > 90 {
+			add("%s latitude %.6f out of range", name, w.Position.Latitude)
+		}
+		if w.Position.Longitude < -180 || w.Position.Longitude > 180 {
+			add("%s longitude %.6f out of range", name, w.Position.Longitude)
+		}
+		if w.Type != WaypointUser && w.Type != WaypointAirport && (w.ICAO == nil || w.ICAO.Ident == "") {
+			add("%s has no ICAO ident", name)
+		}
+
+		switch w.AltitudeDescriptor {
+		case "":
+		case AltitudeAt, AltitudeAtOrAbove, AltitudeAtOrBelow:
+			if w.Altitude1 <= 0 {
+				add("%s has %s constraint without Alt1FP", name, w.AltitudeDescriptor)
+			}
+		case AltitudeBetween:
+			if w.Altitude1 <= 0 || w.Altitude2 <= 0 || w.Altitude2 > w.Altitude1 {
+				add("%s BETWEEN constraint needs Alt1FP >= Alt2FP > 0", name)
+			}
+		default:
+			add("%s has unknown altitude constraint %q", name, w.AltitudeDescriptor)
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package flightplan
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	if err := parseSample(t).Validate(); err != nil {
+		t.Fatalf("sample plan: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(p *Plan)
+		want   string
+	}{
+		{"flight type", func(p *Plan) { p.Type = "SVFR" }, "FPType must be IFR or VFR"},
+		{"departure", func(p *Plan) { p.DepartureID = "" }, "DepartureID is missing"},
+		{"first waypoint", func(p *Plan) { p.DepartureID = "KBFI" }, "first waypoint KSEA does not match DepartureID KBFI"},
+		{"too few waypoints", func(p *Plan) { p.Waypoints = p.Waypoints[:1] }, "at least two waypoints"},
+		{"latitude", func(p *Plan) { p.Waypoints[1].Position.Latitude = 91 }, "latitude 91.000000 out of range"},
+		{"ICAO ident", func(p *Plan) { p.Waypoints[1].ICAO = nil }, "waypoint 1 (SEA) has no ICAO ident"},
+		{"constraint", func(p *Plan) { p.Waypoints[1].Altitude1 = 0 }, "AT_OR_ABOVE constraint without Alt1FP"},
+		{"between", func(p *Plan) { p.Waypoints[2].Altitude2 = 30000 }, "BETWEEN constraint needs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := parseSample(t)
+			tt.modify(plan)
+
+			err := plan.Validate()
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate() = %v, want *ValidationError", err)
+			}
+			if !strings.Contains(verr.Error(), tt.want) {
+				t.Errorf("Validate() = %q, want it to mention %q", verr.Error(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,443 @@
+package lvar
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// ErrNotConnected is returned when a command is sent before Connect completed
+var ErrNotConnected = errors.New("lvar: bridge not connected")
+
+// DefaultIDBase is the first client data, definition and request ID the bridge uses;
+// override it in Options if it clashes with IDs of the application
+const DefaultIDBase uint32 = 0xEE000000
+
+// ID offsets from Options.IDBase
+const (
+	offsetDefaultChannel = 0     // Client data IDs of the default channel (3)
+	offsetClientChannel  = 3     // Client data IDs of the named client channel (3)
+	offsetString         = 0     // Definition of the command and response strings
+	offsetResponse       = 0     // Requests of the response areas (default, client)
+	offsetVariables      = 0x100 // Definition and request IDs of the variables, one per slot
+)
+
+// Conn issues the client data calls the bridge needs; *client.Engine satisfies it
+type Conn interface {
+	MapClientDataNameToID(name string, clientDataID uint32) error
+	AddToClientDataDefinition(defID uint32, offset uint32, sizeOrType types.ClientDataType, epsilon float32, datumID uint32) error
+	ClearClientDataDefinition(defID uint32) error
+	RequestClientData(clientDataID uint32, requestID uint32, defID uint32, period types.ClientDataPeriod, flags uint32) error
+	SetClientData(clientDataID uint32, defID uint32, flags uint32, data []byte) error
+}
+
+// Options configures a Bridge
+type Options struct {
+	ClientName string  // Registers a private channel with the module; empty stays on DefaultChannel
+	IDBase     uint32  // Defaults to DefaultIDBase
+	Epsilon    float32 // Smallest change of a variable that is reported (0 reports every change)
+	Now        func() time.Time
+}
+
+// Variable is a registered variable and its last received value
+type Variable struct {
+	Name      string    `json:"name"`  // Expression read by the module, e.g. "(L:A32NX_AUTOPILOT_1_ACTIVE)"
+	Index     int       `json:"index"` // Slot in the float area
+	Value     float64   `json:"value"`
+	Valid     bool      `json:"valid"` // False until the first value arrived
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// slot is the bridge's bookkeeping of a registered variable
+type slot struct {
+	Variable
+	ready chan struct{} // Closed when the first value arrives
+}
+
+// Bridge talks to the MobiFlight WASM module over client data. Feed every message
+// from Listen() into Process; replies and values are only delivered from there.
+// It is safe for concurrent use; callbacks run on the goroutine calling Process.
+type Bridge struct {
+	conn Conn
+	opts Options
+
+	mu        sync.Mutex
+	active    *channel // Channel commands are sent on, nil before Connect
+	slots     []*slot
+	byName    map[string]*slot
+	waiters   map[string][]chan struct{} // Expected response → waiting callers
+	onChange  []func(Variable)
+	responses []func(string)
+}
+
+// New creates a bridge on conn; call Connect once Listen() is running
+func New(conn Conn, opts Options) *Bridge {
+	if opts.IDBase == 0 {
+		opts.IDBase = DefaultIDBase
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &Bridge{
+		conn:    conn,
+		opts:    opts,
+		byName:  make(map[string]*slot),
+		waiters: make(map[string][]chan struct{}),
+	}
+}
+
+// Connect opens the default channel, checks that the module answers and, when
+// Options.ClientName is set, registers the client and switches to its channel
+func (b *Bridge) Connect(ctx context.Context) error {
+	def := b.channel(DefaultChannel, offsetDefaultChannel)
+	if err := b.open(def); err != nil {
+		return err
+	}
+	if err := b.conn.AddToClientDataDefinition(b.opts.IDBase+offsetString, 0, types.ClientDataType(MessageSize), 0, 0); err != nil {
+		return err
+	}
+	if err := b.subscribe(def, offsetResponse); err != nil {
+		return err
+	}
+
+	// The first command after mapping the areas can be lost, so a dummy goes first
+	if err := b.sendOn(def, cmdDummy); err != nil {
+		return err
+	}
+	if err := b.roundTrip(ctx, def, cmdPing, cmdPong); err != nil {
+		return fmt.Errorf("lvar: WASM module not responding: %w", err)
+	}
+
+	active := def
+	if b.opts.ClientName != "" {
+		add := cmdClientAdd + b.opts.ClientName
+		if err := b.roundTrip(ctx, def, add, add+suffixFinished); err != nil {
+			return fmt.Errorf("lvar: registering client %q: %w", b.opts.ClientName, err)
+		}
+		active = b.channel(b.opts.ClientName, offsetClientChannel)
+		if err := b.open(active); err != nil {
+			return err
+		}
+		if err := b.subscribe(active, offsetResponse+1); err != nil {
+			return err
+		}
+	}
+
+	// Start from an empty variable list; a reconnecting client may have left one behind
+	if err := b.sendOn(active, cmdClear); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	b.active = active
+	b.mu.Unlock()
+	return nil
+}
+
+// Register adds a variable to the float area and subscribes to its changes.
+// name is an L:var ("A32NX_ENGINE_N1:1", "L:XMLVAR_Baro1_Mode") or any expression
+// in parentheses; registering the same expression twice returns the existing slot.
+func (b *Bridge) Register(name string) (Variable, error) {
+	expr := Expression(name)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if s, exists := b.byName[expr]; exists {
+		return s.Variable, nil
+	}
+	if b.active == nil {
+		return Variable{}, ErrNotConnected
+	}
+	if len(b.slots) >= MaxVariables {
+		return Variable{}, fmt.Errorf("lvar: cannot register %s: all %d variables in use", expr, MaxVariables)
+	}
+
+	index := len(b.slots)
+	id := b.opts.IDBase + offsetVariables + uint32(index)
+
+	if err := b.conn.AddToClientDataDefinition(id, uint32(index*ValueSize), types.SIMCONNECT_CLIENTDATATYPE_FLOAT32, b.opts.Epsilon, 0); err != nil {
+		return Variable{}, err
+	}
+	if err := b.conn.RequestClientData(b.active.lvars, id, id, types.SIMCONNECT_CLIENT_DATA_PERIOD_ON_SET, types.SIMCONNECT_CLIENT_DATA_REQUEST_FLAG_CHANGED); err != nil {
+		return Variable{}, err
+	}
+	if err := b.sendOn(b.active, cmdAddVariable+expr); err != nil {
+		return Variable{}, err
+	}
+
+	s := &slot{Variable: Variable{Name: expr, Index: index}, ready: make(chan struct{})}
+	b.slots = append(b.slots, s)
+	b.byName[expr] = s
+	return s.Variable, nil
+}
+
+// Value returns the last received value of a registered variable
+func (b *Bridge) Value(name string) (float64, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s, exists := b.byName[Expression(name)]
+	if !exists || !s.Valid {
+		return 0, false
+	}
+	return s.Value, true
+}
+
+// Read registers the variable if needed and waits for its first value
+func (b *Bridge) Read(ctx context.Context, name string) (float64, error) {
+	v, err := b.Register(name)
+	if err != nil {
+		return 0, err
+	}
+
+	b.mu.Lock()
+	s, exists := b.byName[v.Name]
+	b.mu.Unlock()
+
+	if !exists {
+		return 0, fmt.Errorf("lvar: %s was cleared while waiting", v.Name)
+	}
+
+	select {
+	case <-s.ready:
+		value, _ := b.Value(v.Name)
+		return value, nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+// Variables returns a snapshot of the registered variables in slot order
+func (b *Bridge) Variables() []Variable {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	vars := make([]Variable, len(b.slots))
+	for i, s := range b.slots {
+		vars[i] = s.Variable
+	}
+	return vars
+}
+
+// Set writes value to an L:var, e.g. Set("A32NX_AUTOPILOT_HEADING_SELECTED", 270)
+func (b *Bridge) Set(name string, value float64) error {
+	return b.Execute(setCode(Expression(name), value))
+}
+
+// Trigger fires an H:event, e.g. Trigger("A320_Neo_CDU_1_BTN_INIT")
+func (b *Bridge) Trigger(event string) error {
+	return b.Execute(triggerCode(event))
+}
+
+// Execute runs calculator (RPN) code in the simulator, e.g. "1 (>K:TOGGLE_NAV_LIGHTS)"
+func (b *Bridge) Execute(code string) error {
+	return b.send(cmdSet + code)
+}
+
+// Ping checks that the module still answers on the active channel
+func (b *Bridge) Ping(ctx context.Context) error {
+	b.mu.Lock()
+	active := b.active
+	b.mu.Unlock()
+
+	if active == nil {
+		return ErrNotConnected
+	}
+	return b.roundTrip(ctx, active, cmdPing, cmdPong)
+}
+
+// Clear removes every registered variable from the module and stops their updates
+func (b *Bridge) Clear() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.active == nil {
+		return ErrNotConnected
+	}
+	if err := b.sendOn(b.active, cmdClear); err != nil {
+		return err
+	}
+
+	for _, s := range b.slots {
+		id := b.opts.IDBase + offsetVariables + uint32(s.Index)
+		if err := b.conn.RequestClientData(b.active.lvars, id, id, types.SIMCONNECT_CLIENT_DATA_PERIOD_NEVER, 0); err != nil {
+			return err
+		}
+		if err := b.conn.ClearClientDataDefinition(id); err != nil {
+			return err
+		}
+	}
+	b.slots = nil
+	b.byName = make(map[string]*slot)
+	return nil
+}
+
+// OnChange registers a callback invoked whenever a variable receives a new value
+func (b *Bridge) OnChange(fn func(Variable)) {
+	b.mu.Lock()
+	b.onChange = append(b.onChange, fn)
+	b.mu.Unlock()
+}
+
+// OnResponse registers a callback invoked with every string the module writes to the response area
+func (b *Bridge) OnResponse(fn func(string)) {
+	b.mu.Lock()
+	b.responses = append(b.responses, fn)
+	b.mu.Unlock()
+}
+
+// Process feeds a message from Listen() into the bridge; messages not meant for it are ignored
+func (b *Bridge) Process(msg any) {
+	data, ok := types.IsClientData(msg)
+	if !ok {
+		return
+	}
+	raw, ok := data.Data.([]byte)
+	if !ok {
+		return
+	}
+
+	base := b.opts.IDBase
+	switch {
+	case data.RequestID == base+offsetResponse || data.RequestID == base+offsetResponse+1:
+		b.handleResponse(decodeResponse(raw))
+	case data.RequestID >= base+offsetVariables && data.RequestID < base+offsetVariables+MaxVariables:
+		if len(raw) >= ValueSize {
+			value := math.Float32frombits(binary.LittleEndian.Uint32(raw))
+			b.handleValue(int(data.RequestID-base-offsetVariables), float64(value))
+		}
+	}
+}
+
+// handleResponse wakes the callers waiting for a response and runs the callbacks
+func (b *Bridge) handleResponse(response string) {
+	b.mu.Lock()
+	waiters := b.waiters[response]
+	delete(b.waiters, response)
+	callbacks := append([]func(string){}, b.responses...)
+	b.mu.Unlock()
+
+	for _, ch := range waiters {
+		close(ch)
+	}
+	for _, fn := range callbacks {
+		fn(response)
+	}
+}
+
+// handleValue stores a received value and runs the callbacks
+func (b *Bridge) handleValue(index int, value float64) {
+	b.mu.Lock()
+	if index >= len(b.slots) {
+		b.mu.Unlock()
+		return
+	}
+	s := b.slots[index]
+	first := !s.Valid
+	s.Value = value
+	s.Valid = true
+	s.UpdatedAt = b.opts.Now()
+	v := s.Variable
+	callbacks := append([]func(Variable){}, b.onChange...)
+	b.mu.Unlock()
+
+	if first {
+		close(s.ready)
+	}
+	for _, fn := range callbacks {
+		fn(v)
+	}
+}
+
+// roundTrip sends a command and waits for the expected response
+func (b *Bridge) roundTrip(ctx context.Context, ch *channel, cmd string, expected string) error {
+	wait := make(chan struct{})
+
+	// Register before sending so a fast response cannot be missed
+	b.mu.Lock()
+	b.waiters[expected] = append(b.waiters[expected], wait)
+	b.mu.Unlock()
+
+	if err := b.sendOn(ch, cmd); err != nil {
+		b.forget(expected, wait)
+		return err
+	}
+
+	select {
+	case <-wait:
+		return nil
+	case <-ctx.Done():
+		b.forget(expected, wait)
+		return ctx.Err()
+	}
+}
+
+// forget drops a response waiter
+func (b *Bridge) forget(expected string, wait chan struct{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	waiters := b.waiters[expected]
+	for i, w := range waiters {
+		if w == wait {
+			b.waiters[expected] = append(waiters[:i], waiters[i+1:]...)
+			break
+		}
+	}
+	if len(b.waiters[expected]) == 0 {
+		delete(b.waiters, expected)
+	}
+}
+
+// send writes a command to the active channel
+func (b *Bridge) send(cmd string) error {
+	b.mu.Lock()
+	active := b.active
+	b.mu.Unlock()
+
+	if active == nil {
+		return ErrNotConnected
+	}
+	return b.sendOn(active, cmd)
+}
+
+// sendOn writes a command to the command area of a channel
+func (b *Bridge) sendOn(ch *channel, cmd string) error {
+	data, err := encodeCommand(cmd)
+	if err != nil {
+		return err
+	}
+	return b.conn.SetClientData(ch.command, b.opts.IDBase+offsetString, types.SIMCONNECT_CLIENT_DATA_SET_FLAG_DEFAULT, data)
+}
+
+// channel returns the client data IDs of a named set of areas
+func (b *Bridge) channel(name string, offset uint32) *channel {
+	id := b.opts.IDBase + offset
+	return &channel{name: name, lvars: id, command: id + 1, response: id + 2}
+}
+
+// open maps the area names of a channel to its client data IDs
+func (b *Bridge) open(ch *channel) error {
+	for _, area := range []struct {
+		suffix string
+		id     uint32
+	}{{suffixLVars, ch.lvars}, {suffixCommand, ch.command}, {suffixResponse, ch.response}} {
+		if err := b.conn.MapClientDataNameToID(ch.name+area.suffix, area.id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// subscribe requests every change of a channel's response area
+func (b *Bridge) subscribe(ch *channel, requestOffset uint32) error {
+	return b.conn.RequestClientData(ch.response, b.opts.IDBase+requestOffset, b.opts.IDBase+offsetString,
+		types.SIMCONNECT_CLIENT_DATA_PERIOD_ON_SET, types.SIMCONNECT_CLIENT_DATA_REQUEST_FLAG_CHANGED)
+}
@@ -0,0 +1,340 @@
+package lvar
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// fakeModule stands in for the MobiFlight WASM module: it records the client data
+// calls of the bridge and answers commands the way the module does
+type fakeModule struct {
+	t      *testing.T
+	bridge *Bridge
+
+	mu          sync.Mutex
+	areas       map[string]uint32 // Area name → client data ID
+	responseReq map[uint32]uint32 // Response area ID → request ID delivering it
+	definitions map[uint32]bool   // Live definition IDs
+	requests    map[uint32]uint32 // Live variable request ID → definition ID
+	commands    []string          // Commands in the order received
+	variables   []string          // Variables of the module's float area
+	cleared     []uint32          // Definitions cleared, in order
+}
+
+func newFakeModule(t *testing.T) *fakeModule {
+	return &fakeModule{
+		t:           t,
+		areas:       make(map[string]uint32),
+		responseReq: make(map[uint32]uint32),
+		definitions: make(map[uint32]bool),
+		requests:    make(map[uint32]uint32),
+	}
+}
+
+func (m *fakeModule) MapClientDataNameToID(name string, clientDataID uint32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.areas[name] = clientDataID
+	return nil
+}
+
+func (m *fakeModule) AddToClientDataDefinition(defID uint32, offset uint32, sizeOrType types.ClientDataType, epsilon float32, datumID uint32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.definitions[defID] {
+		m.t.Errorf("definition %#x added twice without clearing", defID)
+	}
+	m.definitions[defID] = true
+	return nil
+}
+
+func (m *fakeModule) ClearClientDataDefinition(defID uint32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.definitions, defID)
+	m.cleared = append(m.cleared, defID)
+	return nil
+}
+
+func (m *fakeModule) RequestClientData(clientDataID uint32, requestID uint32, defID uint32, period types.ClientDataPeriod, flags uint32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.isResponseArea(clientDataID) {
+		m.responseReq[clientDataID] = requestID
+		return nil
+	}
+	if period == types.SIMCONNECT_CLIENT_DATA_PERIOD_NEVER {
+		delete(m.requests, requestID)
+	} else {
+		m.requests[requestID] = defID
+	}
+	return nil
+}
+
+func (m *fakeModule) SetClientData(clientDataID uint32, defID uint32, flags uint32, data []byte) error {
+	cmd := decodeResponse(data)
+
+	m.mu.Lock()
+	m.commands = append(m.commands, cmd)
+	responseReq := m.responseReq[clientDataID+1] // The response area follows the command area
+	var reply string
+	switch {
+	case cmd == cmdPing:
+		reply = cmdPong
+	case strings.HasPrefix(cmd, cmdClientAdd):
+		reply = cmd + suffixFinished
+	case strings.HasPrefix(cmd, cmdAddVariable):
+		m.variables = append(m.variables, strings.TrimPrefix(cmd, cmdAddVariable))
+	case cmd == cmdClear:
+		m.variables = nil
+	}
+	m.mu.Unlock()
+
+	// The module answers asynchronously, through the dispatch loop
+	if reply != "" {
+		go m.bridge.Process(clientDataMessage(responseReq, []byte(reply+"\x00")))
+	}
+	return nil
+}
+
+// isResponseArea reports whether a client data ID is mapped to a response area; m.mu must be held
+func (m *fakeModule) isResponseArea(id uint32) bool {
+	for name, areaID := range m.areas {
+		if areaID == id && strings.HasSuffix(name, suffixResponse) {
+			return true
+		}
+	}
+	return false
+}
+
+// publish writes a value into the float area of a variable, as the module does on change
+func (m *fakeModule) publish(index int, value float32) {
+	m.mu.Lock()
+	var requestID uint32
+	for id := range m.requests {
+		if id == m.bridge.opts.IDBase+offsetVariables+uint32(index) {
+			requestID = id
+		}
+	}
+	m.mu.Unlock()
+
+	if requestID == 0 {
+		m.t.Errorf("no live request for slot %d", index)
+		return
+	}
+	raw := make([]byte, ValueSize)
+	binary.LittleEndian.PutUint32(raw, math.Float32bits(value))
+	m.bridge.Process(clientDataMessage(requestID, raw))
+}
+
+func clientDataMessage(requestID uint32, raw []byte) map[string]any {
+	return map[string]any{
+		"type":        "CLIENT_DATA",
+		"client_data": &types.ClientData{RequestID: requestID, Data: raw},
+	}
+}
+
+func connectedBridge(t *testing.T, opts Options) (*Bridge, *fakeModule) {
+	t.Helper()
+	module := newFakeModule(t)
+	bridge := New(module, opts)
+	module.bridge = bridge
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := bridge.Connect(ctx); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	return bridge, module
+}
+
+func TestConnect(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     Options
+		commands []string
+		channel  string
+	}{
+		{
+			name:     "default channel",
+			commands: []string{cmdDummy, cmdPing, cmdClear},
+			channel:  DefaultChannel,
+		},
+		{
+			name:     "client channel",
+			opts:     Options{ClientName: "SDKTest"},
+			commands: []string{cmdDummy, cmdPing, cmdClientAdd + "SDKTest", cmdClear},
+			channel:  "SDKTest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, module := connectedBridge(t, tt.opts)
+
+			if got := strings.Join(module.commands, " "); got != strings.Join(tt.commands, " ") {
+				t.Errorf("commands = %s, want %s", got, strings.Join(tt.commands, " "))
+			}
+			for _, suffix := range []string{suffixLVars, suffixCommand, suffixResponse} {
+				if _, ok := module.areas[tt.channel+suffix]; !ok {
+					t.Errorf("area %s%s not mapped", tt.channel, suffix)
+				}
+			}
+		})
+	}
+}
+
+func TestConnectNoModule(t *testing.T) {
+	module := newFakeModule(t)
+	bridge := New(&silentConn{module}, Options{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := bridge.Connect(ctx); err == nil {
+		t.Fatal("Connect succeeded without a module")
+	}
+	if _, err := bridge.Register("A32NX_ENGINE_N1_1"); err != ErrNotConnected {
+		t.Errorf("Register err = %v, want ErrNotConnected", err)
+	}
+}
+
+// silentConn accepts every call but never answers, like a simulator without the module
+type silentConn struct{ *fakeModule }
+
+func (c *silentConn) SetClientData(clientDataID uint32, defID uint32, flags uint32, data []byte) error {
+	return nil
+}
+
+func TestRegisterRead(t *testing.T) {
+	bridge, module := connectedBridge(t, Options{})
+
+	v, err := bridge.Register("A32NX_ENGINE_N1_1")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if v.Name != "(L:A32NX_ENGINE_N1_1)" || v.Index != 0 {
+		t.Errorf("Register = %+v", v)
+	}
+	if again, _ := bridge.Register("(L:A32NX_ENGINE_N1_1)"); again.Index != 0 || len(module.variables) != 1 {
+		t.Errorf("registering the same expression twice added a slot: %+v, module has %v", again, module.variables)
+	}
+
+	go module.publish(0, 84.5)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	value, err := bridge.Read(ctx, "A32NX_ENGINE_N1_1")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if value != 84.5 {
+		t.Errorf("Read = %v, want 84.5", value)
+	}
+
+	second, err := bridge.Register("L:XMLVAR_Baro1_Mode")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if second.Index != 1 {
+		t.Errorf("second Index = %d, want 1", second.Index)
+	}
+	module.publish(1, 2)
+	if value, ok := bridge.Value("XMLVAR_Baro1_Mode"); !ok || value != 2 {
+		t.Errorf("Value = %v, %v, want 2", value, ok)
+	}
+	if value, _ := bridge.Value("A32NX_ENGINE_N1_1"); value != 84.5 {
+		t.Errorf("first variable changed to %v", value)
+	}
+}
+
+func TestClearReusesSlots(t *testing.T) {
+	bridge, module := connectedBridge(t, Options{})
+	firstID := bridge.opts.IDBase + offsetVariables
+
+	if _, err := bridge.Register("A32NX_ENGINE_N1_1"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	module.publish(0, 84.5)
+
+	if err := bridge.Clear(); err != nil {
+		t.Fatalf("Clear: %v", err)
+	}
+	if len(module.variables) != 0 || len(module.requests) != 0 || len(module.definitions) != 1 {
+		t.Errorf("after Clear: variables %v, requests %v, definitions %v", module.variables, module.requests, module.definitions)
+	}
+	if len(module.cleared) != 1 || module.cleared[0] != firstID {
+		t.Errorf("cleared definitions = %#x, want [%#x]", module.cleared, firstID)
+	}
+	if _, ok := bridge.Value("A32NX_ENGINE_N1_1"); ok {
+		t.Error("cleared variable still has a value")
+	}
+
+	// A value still in flight for the cleared variable is dropped
+	raw := make([]byte, ValueSize)
+	binary.LittleEndian.PutUint32(raw, math.Float32bits(99))
+	bridge.Process(clientDataMessage(firstID, raw))
+	if vars := bridge.Variables(); len(vars) != 0 {
+		t.Errorf("Variables after Clear = %+v", vars)
+	}
+
+	// The next variable takes over slot 0 and its definition and request ID
+	v, err := bridge.Register("A32NX_AUTOPILOT_1_ACTIVE")
+	if err != nil {
+		t.Fatalf("Register after Clear: %v", err)
+	}
+	if v.Index != 0 || v.Valid {
+		t.Errorf("Register after Clear = %+v, want fresh slot 0", v)
+	}
+	if defID, ok := module.requests[firstID]; !ok || defID != firstID {
+		t.Errorf("request %#x not renewed: %v", firstID, module.requests)
+	}
+	if len(module.variables) != 1 || module.variables[0] != "(L:A32NX_AUTOPILOT_1_ACTIVE)" {
+		t.Errorf("module variables = %v", module.variables)
+	}
+
+	go module.publish(0, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	value, err := bridge.Read(ctx, "A32NX_AUTOPILOT_1_ACTIVE")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if value != 1 {
+		t.Errorf("Read = %v, want 1", value)
+	}
+	if _, ok := bridge.Value("A32NX_ENGINE_N1_1"); ok {
+		t.Error("the reused slot answers for the cleared variable")
+	}
+}
+
+func TestSetTriggerExecute(t *testing.T) {
+	bridge, module := connectedBridge(t, Options{})
+
+	if err := bridge.Set("A32NX_AUTOPILOT_HEADING_SELECTED", 270); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := bridge.Trigger("H:A320_Neo_CDU_1_BTN_INIT"); err != nil {
+		t.Fatalf("Trigger: %v", err)
+	}
+	if err := bridge.Execute(strings.Repeat("1 ", MessageSize)); err != ErrTooLong {
+		t.Errorf("Execute of an oversized command err = %v, want ErrTooLong", err)
+	}
+
+	want := []string{
+		cmdSet + "270 (>L:A32NX_AUTOPILOT_HEADING_SELECTED)",
+		cmdSet + "(>H:A320_Neo_CDU_1_BTN_INIT)",
+	}
+	got := module.commands[len(module.commands)-2:]
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("command %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
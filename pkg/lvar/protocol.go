@@ -0,0 +1,91 @@
+// Package lvar reads L:vars, executes calculator code and fires H:events through
+// the client data channels of the MobiFlight WASM module, which must be installed
+// in the simulator's Community folder.
+package lvar
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Client data area names and commands of the MobiFlight WASM module
+const (
+	DefaultChannel = "MobiFlight" // Channel every client starts on; named clients get their own
+
+	suffixLVars    = ".LVars"    // Float area, one 4-byte value per registered variable
+	suffixCommand  = ".Command"  // String area written by the client
+	suffixResponse = ".Response" // String area written by the module
+
+	cmdPing        = "MF.Ping"
+	cmdPong        = "MF.Pong"
+	cmdDummy       = "MF.DummyCmd"
+	cmdClientAdd   = "MF.Clients.Add."
+	cmdAddVariable = "MF.SimVars.Add."
+	cmdClear       = "MF.SimVars.Clear"
+	cmdSet         = "MF.SimVars.Set."
+	suffixFinished = ".Finished"
+)
+
+// Sizes of the module's client data areas
+const (
+	MessageSize  = 256  // Bytes of a command or response string, including the terminating NUL
+	ValueSize    = 4    // Bytes of one variable in the float area (FLOAT32)
+	MaxVariables = 1024 // Variables per client the float area holds
+)
+
+// ErrTooLong is returned when a command does not fit into MessageSize
+var ErrTooLong = errors.New("lvar: command exceeds the message size")
+
+// channel holds the client data IDs of one set of module areas
+type channel struct {
+	name     string
+	lvars    uint32
+	command  uint32
+	response uint32
+}
+
+// encodeCommand lays a command out as the NUL-terminated string the module reads
+func encodeCommand(cmd string) ([]byte, error) {
+	if len(cmd) >= MessageSize {
+		return nil, ErrTooLong
+	}
+	buf := make([]byte, MessageSize)
+	copy(buf, cmd)
+	return buf, nil
+}
+
+// decodeResponse reads the NUL-terminated string of a response area
+func decodeResponse(data []byte) string {
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		data = data[:i]
+	}
+	return string(data)
+}
+
+// Expression returns the calculator code that reads a variable: bare names and
+// "L:NAME" become "(L:NAME)", anything already in parentheses is kept as is,
+// so "(A:PLANE ALTITUDE, feet)" or "(L:VALUE, percent)" can be registered too.
+func Expression(name string) string {
+	name = strings.TrimSpace(name)
+	if strings.HasPrefix(name, "(") {
+		return name
+	}
+	if !strings.Contains(name, ":") {
+		name = "L:" + name
+	}
+	return "(" + name + ")"
+}
+
+// setCode returns the calculator code that writes value to the variable read by expr
+func setCode(expr string, value float64) string {
+	inner := strings.TrimSuffix(strings.TrimPrefix(expr, "("), ")")
+	return strconv.FormatFloat(value, 'f', -1, 64) + " (>" + inner + ")"
+}
+
+// triggerCode returns the calculator code that fires an H:event
+func triggerCode(event string) string {
+	event = strings.TrimPrefix(strings.TrimSpace(event), "H:")
+	return "(>H:" + event + ")"
+}
@@ -0,0 +1,348 @@
+// Package navdata keeps a local, persistent cache of facility data so airports
+// and navaids can be looked up without repeating facility queries, and while
+// the simulator is not running.
+package navdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mycrew-online/sdk/pkg/facilities"
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// ErrNotFound is returned when a facility is neither cached nor available from a source
+var ErrNotFound = errors.New("navdata: facility not found")
+
+// Default definition IDs registered on the source; override them in Options if they clash
+const (
+	DefaultAirportDefinitionID uint32 = 0xEF000001
+	DefaultNavaidDefinitionID  uint32 = 0xEF000002
+)
+
+// Source fetches facilities from the simulator; *client.Engine satisfies it
+type Source interface {
+	RegisterFacilityDefinition(defID uint32, def *facilities.Definition) error
+	RequestFacility(ctx context.Context, defID uint32, icao string, region string) (*facilities.Node, error)
+	RequestFacilities(ctx context.Context, listType types.FacilityListType, minimal bool) (*types.FacilityListData, error)
+}
+
+// Options configures a Cache
+type Options struct {
+	Path                string        // JSON-lines file; empty keeps the cache in memory only
+	AirportTTL          time.Duration // Age after which full airport records are refetched (0 = never)
+	NavaidTTL           time.Duration // Age after which navaid records are refetched (0 = never)
+	ListTTL             time.Duration // Age after which facility list entries are refetched (0 = never)
+	AirportDefinitionID uint32        // Defaults to DefaultAirportDefinitionID
+	NavaidDefinitionID  uint32        // Defaults to DefaultNavaidDefinitionID
+	Now                 func() time.Time
+}
+
+// Cache answers facility lookups from the local store and refreshes stale
+// entries from the attached Source. When the source is missing or fails,
+// stale entries are still returned so lookups keep working offline.
+type Cache struct {
+	store *store
+	opts  Options
+
+	mu         sync.Mutex
+	source     Source
+	registered bool
+}
+
+// Open loads the cache file at opts.Path
+func Open(opts Options) (*Cache, error) {
+	if opts.AirportDefinitionID == 0 {
+		opts.AirportDefinitionID = DefaultAirportDefinitionID
+	}
+	if opts.NavaidDefinitionID == 0 {
+		opts.NavaidDefinitionID = DefaultNavaidDefinitionID
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	s, err := openStore(opts.Path)
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{store: s, opts: opts}, nil
+}
+
+// Attach sets the source used to fetch missing or stale facilities; nil detaches it
+func (c *Cache) Attach(src Source) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.source = src
+	c.registered = false
+}
+
+// Airport returns the airport with the given ICAO ident
+func (c *Cache) Airport(ctx context.Context, icao string) (*facilities.Airport, error) {
+	cached := c.cachedAirport(icao)
+	if cached != nil && c.fresh(cached, c.opts.AirportTTL) {
+		return cached.Airport, nil
+	}
+
+	node, err := c.fetch(ctx, c.opts.AirportDefinitionID, icao, "")
+	if err == nil {
+		var airport *facilities.Airport
+		if airport, err = node.Airport(); err == nil {
+			if err = c.PutAirport(airport); err == nil {
+				return airport, nil
+			}
+		}
+	}
+
+	if cached != nil {
+		return cached.Airport, nil
+	}
+	if errors.Is(err, errNoSource) {
+		return nil, fmt.Errorf("%w: airport %s", ErrNotFound, icao)
+	}
+	return nil, fmt.Errorf("navdata: airport %s: %w", icao, err)
+}
+
+// Navaid returns the VOR, ILS or DME with the given ident and region; an empty region matches any.
+// Offline, a VOR list entry stands in for a navaid that was never fetched.
+func (c *Cache) Navaid(ctx context.Context, icao string, region string) (*facilities.Navaid, error) {
+	cached := c.cached(KindNavaid, icao, region)
+	if cached != nil && c.fresh(cached, c.opts.NavaidTTL) {
+		return cached.Navaid, nil
+	}
+
+	node, err := c.fetch(ctx, c.opts.NavaidDefinitionID, icao, region)
+	if err == nil {
+		var navaid *facilities.Navaid
+		if navaid, err = node.Navaid(); err == nil {
+			if err = c.PutNavaid(navaid); err == nil {
+				return navaid, nil
+			}
+		}
+	}
+
+	if cached != nil {
+		return cached.Navaid, nil
+	}
+	if entry := c.cached(KindVOREntry, icao, region); entry != nil {
+		return entry.Navaid, nil
+	}
+	if errors.Is(err, errNoSource) {
+		return nil, fmt.Errorf("%w: navaid %s/%s", ErrNotFound, icao, region)
+	}
+	return nil, fmt.Errorf("navdata: navaid %s/%s: %w", icao, region, err)
+}
+
+// Nearest returns up to n cached airports ordered by distance from lat/lon.
+// It only reads the cache; call RefreshAirports to populate it from the simulator.
+func (c *Cache) Nearest(lat, lon float64, n int) []types.FacilityAirport {
+	seen := make(map[string]types.FacilityAirport)
+	c.store.each(KindAirportEntry, func(r *Record) {
+		seen[r.Key] = *r.Entry
+	})
+	// Full airport records cover airports that were only ever looked up by ident
+	c.store.each(KindAirport, func(r *Record) {
+		key := recordKey(KindAirportEntry, r.Airport.ICAO, r.Airport.Region)
+		if _, ok := seen[key]; !ok {
+			seen[key] = types.FacilityAirport{
+				Ident:     r.Airport.ICAO,
+				Region:    r.Airport.Region,
+				Latitude:  r.Airport.Latitude,
+				Longitude: r.Airport.Longitude,
+				Altitude:  r.Airport.Altitude,
+			}
+		}
+	})
+
+	airports := make([]types.FacilityAirport, 0, len(seen))
+	for _, a := range seen {
+		airports = append(airports, a)
+	}
+	// Stable input order keeps ties deterministic
+	sort.Slice(airports, func(i, j int) bool { return airports[i].Ident < airports[j].Ident })
+	return facilities.NearestAirports(airports, lat, lon, n)
+}
+
+// RefreshAirports pulls the airport list of the simulator's reality bubble into the cache
+func (c *Cache) RefreshAirports(ctx context.Context) (int, error) {
+	src, err := c.attached()
+	if err != nil {
+		return 0, err
+	}
+	list, err := src.RequestFacilities(ctx, types.SIMCONNECT_FACILITY_LIST_TYPE_AIRPORT, false)
+	if err != nil {
+		return 0, fmt.Errorf("navdata: airport list: %w", err)
+	}
+	return list.Len(), c.PutList(list)
+}
+
+// AirportListStale reports whether the cached airport list is missing or older than ListTTL
+func (c *Cache) AirportListStale() bool {
+	var oldest *Record
+	c.store.each(KindAirportEntry, func(r *Record) {
+		if oldest == nil || r.FetchedAt.Before(oldest.FetchedAt) {
+			oldest = r
+		}
+	})
+	return oldest == nil || !c.fresh(oldest, c.opts.ListTTL)
+}
+
+// PutAirport stores a full airport record
+func (c *Cache) PutAirport(airport *facilities.Airport) error {
+	return c.store.put(&Record{
+		Key:       recordKey(KindAirport, airport.ICAO, airport.Region),
+		Kind:      KindAirport,
+		FetchedAt: c.opts.Now().UTC(),
+		Airport:   airport,
+	})
+}
+
+// PutNavaid stores a navaid record
+func (c *Cache) PutNavaid(navaid *facilities.Navaid) error {
+	return c.store.put(&Record{
+		Key:       recordKey(KindNavaid, navaid.ICAO, navaid.Region),
+		Kind:      KindNavaid,
+		FetchedAt: c.opts.Now().UTC(),
+		Navaid:    navaid,
+	})
+}
+
+// PutList stores the entries of a facility list. VOR entries are kept apart from fetched navaids,
+// so a list never overwrites a full record or refreshes its age.
+func (c *Cache) PutList(list *types.FacilityListData) error {
+	if list == nil {
+		return nil
+	}
+	now := c.opts.Now().UTC()
+	var records []*Record
+
+	for i := range list.Airports {
+		a := list.Airports[i]
+		records = append(records, &Record{Key: recordKey(KindAirportEntry, a.Ident, a.Region), Kind: KindAirportEntry, FetchedAt: now, Entry: &a})
+	}
+	for i := range list.Waypoints {
+		w := list.Waypoints[i]
+		records = append(records, &Record{Key: recordKey(KindWaypoint, w.Ident, w.Region), Kind: KindWaypoint, FetchedAt: now, Waypoint: &w})
+	}
+	for i := range list.NDBs {
+		n := list.NDBs[i]
+		records = append(records, &Record{Key: recordKey(KindNDB, n.Ident, n.Region), Kind: KindNDB, FetchedAt: now, NDB: &n})
+	}
+	for _, v := range list.VORs {
+		records = append(records, &Record{
+			Key:       recordKey(KindVOREntry, v.Ident, v.Region),
+			Kind:      KindVOREntry,
+			FetchedAt: now,
+			Navaid: &facilities.Navaid{
+				ICAO:          v.Ident,
+				Region:        v.Region,
+				MHz:           float64(v.Frequency) / 1e6,
+				Latitude:      v.Latitude,
+				Longitude:     v.Longitude,
+				Altitude:      v.Altitude,
+				HasGlideSlope: v.Flags&types.SIMCONNECT_RECV_ID_VOR_LIST_HAS_GLIDE_SLOPE != 0,
+				Localizer:     float64(v.Localizer),
+				GlideSlope:    float64(v.GlideSlopeAngle),
+				MagVar:        float64(v.MagVar),
+			},
+		})
+	}
+
+	if len(records) == 0 {
+		return nil
+	}
+	return c.store.put(records...)
+}
+
+// PutResult stores a decoded facility request, e.g. one replayed from recorded payloads
+func (c *Cache) PutResult(result *facilities.Result) error {
+	if result == nil || result.Root == nil {
+		return nil
+	}
+	switch result.Root.Type {
+	case types.SIMCONNECT_FACILITY_DATA_AIRPORT:
+		airport, err := result.Root.Airport()
+		if err != nil {
+			return err
+		}
+		return c.PutAirport(airport)
+	case types.SIMCONNECT_FACILITY_DATA_VOR:
+		navaid, err := result.Root.Navaid()
+		if err != nil {
+			return err
+		}
+		return c.PutNavaid(navaid)
+	}
+	return fmt.Errorf("navdata: unsupported facility type %s", types.GetFacilityDataTypeName(result.Root.Type))
+}
+
+// Remove drops a record of the given kind from the cache
+func (c *Cache) Remove(kind, icao, region string) error {
+	return c.store.remove(recordKey(kind, icao, region))
+}
+
+// Compact rewrites the cache file so it holds one line per facility
+func (c *Cache) Compact() error {
+	return c.store.compact()
+}
+
+var errNoSource = errors.New("no source attached")
+
+// attached returns the source, registering the cache definitions on first use
+func (c *Cache) attached() (Source, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.source == nil {
+		return nil, errNoSource
+	}
+	if !c.registered {
+		if err := c.source.RegisterFacilityDefinition(c.opts.AirportDefinitionID, facilities.AirportDefinition()); err != nil {
+			return nil, err
+		}
+		if err := c.source.RegisterFacilityDefinition(c.opts.NavaidDefinitionID, facilities.NavaidDefinition()); err != nil {
+			return nil, err
+		}
+		c.registered = true
+	}
+	return c.source, nil
+}
+
+// fetch requests one facility from the source
+func (c *Cache) fetch(ctx context.Context, defID uint32, icao, region string) (*facilities.Node, error) {
+	src, err := c.attached()
+	if err != nil {
+		return nil, err
+	}
+	return src.RequestFacility(ctx, defID, strings.ToUpper(strings.TrimSpace(icao)), strings.ToUpper(strings.TrimSpace(region)))
+}
+
+// cachedAirport finds a full airport record by ident in any region
+func (c *Cache) cachedAirport(icao string) *Record {
+	return c.cached(KindAirport, icao, "")
+}
+
+// cached finds a record by ident and region; with an empty region the newest record in any region
+func (c *Cache) cached(kind, icao, region string) *Record {
+	if region != "" {
+		r, _ := c.store.get(recordKey(kind, icao, region))
+		return r
+	}
+	var newest *Record
+	for _, r := range c.store.lookup(kind, icao) {
+		if newest == nil || r.FetchedAt.After(newest.FetchedAt) {
+			newest = r
+		}
+	}
+	return newest
+}
+
+// fresh reports whether a record is younger than ttl; a zero ttl never expires
+func (c *Cache) fresh(r *Record, ttl time.Duration) bool {
+	return ttl <= 0 || c.opts.Now().Sub(r.FetchedAt) < ttl
+}
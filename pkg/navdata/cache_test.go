@@ -0,0 +1,542 @@
+package navdata
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mycrew-online/sdk/pkg/facilities"
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// fakeSource replays FACILITY_DATA records recorded in testdata/<ICAO>.facility.jsonl through
+// facilities.Assembler, the way the client assembles a live reply, and counts requests
+type fakeSource struct {
+	definitions map[uint32]*facilities.Definition
+	offline     map[string]bool // Idents whose requests fail
+	list        *types.FacilityListData
+	requests    int
+}
+
+func (s *fakeSource) RegisterFacilityDefinition(defID uint32, def *facilities.Definition) error {
+	s.definitions[defID] = def
+	return nil
+}
+
+func (s *fakeSource) RequestFacility(ctx context.Context, defID uint32, icao string, region string) (*facilities.Node, error) {
+	s.requests++
+	def, ok := s.definitions[defID]
+	if !ok {
+		return nil, fmt.Errorf("definition %#x not registered", defID)
+	}
+	if s.offline[icao] {
+		return nil, errors.New("facility not found")
+	}
+	records, err := loadRecording(icao)
+	if err != nil {
+		return nil, err
+	}
+
+	a := facilities.NewAssembler(def)
+	for i := range records {
+		if _, err := a.Add(&records[i]); err != nil {
+			return nil, err
+		}
+	}
+	return a.Root(), nil
+}
+
+func (s *fakeSource) RequestFacilities(ctx context.Context, listType types.FacilityListType, minimal bool) (*types.FacilityListData, error) {
+	s.requests++
+	if s.list == nil {
+		return nil, errors.New("list unavailable")
+	}
+	return s.list, nil
+}
+
+// loadRecording reads the FACILITY_DATA records of one facility request
+func loadRecording(icao string) ([]types.FacilityData, error) {
+	f, err := os.Open(filepath.Join("testdata", icao+".facility.jsonl"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errors.New("facility not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []types.FacilityData
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec types.FacilityData
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, scanner.Err()
+}
+
+// clock is a settable time source for TTL tests
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time { return c.now }
+
+type testCache struct {
+	*Cache
+	path string
+	src  *fakeSource
+	clk  *clock
+}
+
+func openTestCache(t *testing.T, opts Options) *testCache {
+	t.Helper()
+	tc := &testCache{
+		path: filepath.Join(t.TempDir(), "navdata.jsonl"),
+		src:  &fakeSource{definitions: make(map[uint32]*facilities.Definition), offline: make(map[string]bool)},
+		clk:  &clock{now: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)},
+	}
+	tc.Cache = tc.reopen(t, opts)
+	tc.Attach(tc.src)
+	return tc
+}
+
+// reopen opens a second, detached cache on the same file
+func (tc *testCache) reopen(t *testing.T, opts Options) *Cache {
+	t.Helper()
+	opts.Path = tc.path
+	opts.Now = tc.clk.Now
+	cache, err := Open(opts)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	return cache
+}
+
+func TestAirport(t *testing.T) {
+	tc := openTestCache(t, Options{})
+	ctx := context.Background()
+
+	airport, err := tc.Airport(ctx, "lszh")
+	if err != nil {
+		t.Fatalf("Airport: %v", err)
+	}
+	if airport.ICAO != "LSZH" || airport.Region != "LS" || airport.Name != "Zurich" || airport.TransitionAltitude != 5000 {
+		t.Errorf("airport = %+v", airport)
+	}
+
+	var names []string
+	for _, rw := range airport.Runways {
+		names = append(names, rw.Primary.Name+"/"+rw.Secondary.Name)
+	}
+	if got := strings.Join(names, " "); got != "14/32 16/34" {
+		t.Errorf("runways = %s, want 14/32 16/34", got)
+	}
+	rw16 := airport.Runways[1]
+	if rw16.Primary.ThresholdLength != 150 || rw16.Secondary.ThresholdLength != 0 || rw16.Primary.ILSICAO != "IZH" || rw16.Secondary.ILSICAO != "IZS" {
+		t.Errorf("runway 16/34 = %+v", rw16)
+	}
+	if len(airport.Frequencies) != 2 || airport.Frequencies[0].MHz != 118.1 || airport.Frequencies[1].Name != "ZURICH ATIS" {
+		t.Errorf("frequencies = %+v", airport.Frequencies)
+	}
+	if len(airport.Parkings) != 1 || airport.Parkings[0].Label() != "N PARKING 12" {
+		t.Errorf("parkings = %+v", airport.Parkings)
+	}
+
+	// Served from the cache, online and offline
+	if _, err := tc.Airport(ctx, "LSZH"); err != nil || tc.src.requests != 1 {
+		t.Errorf("cached lookup: err %v, %d requests, want 1", err, tc.src.requests)
+	}
+	tc.Attach(nil)
+	if _, err := tc.Airport(ctx, "LSZH"); err != nil {
+		t.Errorf("offline lookup: %v", err)
+	}
+	if _, err := tc.Airport(ctx, "LSGG"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("offline unknown airport err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestAirportTTL(t *testing.T) {
+	tc := openTestCache(t, Options{AirportTTL: 24 * time.Hour})
+	ctx := context.Background()
+
+	if _, err := tc.Airport(ctx, "LSZH"); err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	tc.clk.now = tc.clk.now.Add(25 * time.Hour)
+	if _, err := tc.Airport(ctx, "LSZH"); err != nil || tc.src.requests != 2 {
+		t.Errorf("stale lookup: err %v, %d requests, want 2", err, tc.src.requests)
+	}
+
+	// A failed refetch still serves the stale record
+	tc.clk.now = tc.clk.now.Add(25 * time.Hour)
+	tc.src.offline["LSZH"] = true
+	if _, err := tc.Airport(ctx, "LSZH"); err != nil {
+		t.Errorf("stale fallback: %v", err)
+	}
+}
+
+func TestNavaidLookup(t *testing.T) {
+	tests := []struct {
+		name   string
+		region string
+	}{
+		{"with region", "LS"},
+		{"empty region", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := openTestCache(t, Options{})
+			ctx := context.Background()
+
+			if _, err := tc.Navaid(ctx, "KLO", "LS"); err != nil {
+				t.Fatalf("fetch: %v", err)
+			}
+
+			// Served from the cache, online and offline
+			navaid, err := tc.Navaid(ctx, "KLO", tt.region)
+			if err != nil {
+				t.Fatalf("cached lookup: %v", err)
+			}
+			if navaid.Name != "KLOTEN" || navaid.MHz != 114.85 {
+				t.Errorf("navaid = %+v", navaid)
+			}
+			if tc.src.requests != 1 {
+				t.Errorf("requests = %d, want 1", tc.src.requests)
+			}
+
+			tc.Attach(nil)
+			if _, err := tc.Navaid(ctx, "KLO", tt.region); err != nil {
+				t.Errorf("offline lookup: %v", err)
+			}
+		})
+	}
+}
+
+func TestNavaidTTL(t *testing.T) {
+	tc := openTestCache(t, Options{NavaidTTL: time.Hour})
+	ctx := context.Background()
+
+	if _, err := tc.Navaid(ctx, "KLO", ""); err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	tc.clk.now = tc.clk.now.Add(59 * time.Minute)
+	if _, err := tc.Navaid(ctx, "KLO", ""); err != nil || tc.src.requests != 1 {
+		t.Fatalf("fresh lookup: err %v, %d requests, want 1", err, tc.src.requests)
+	}
+
+	tc.clk.now = tc.clk.now.Add(2 * time.Minute)
+	if _, err := tc.Navaid(ctx, "KLO", ""); err != nil || tc.src.requests != 2 {
+		t.Errorf("stale lookup: err %v, %d requests, want 2", err, tc.src.requests)
+	}
+
+	// A failed refetch still serves the stale record
+	tc.clk.now = tc.clk.now.Add(2 * time.Hour)
+	tc.src.offline["KLO"] = true
+	if _, err := tc.Navaid(ctx, "KLO", ""); err != nil {
+		t.Errorf("stale fallback: %v", err)
+	}
+}
+
+func vorEntry(ident, region string, hz uint32) types.FacilityVOR {
+	return types.FacilityVOR{FacilityNDB: types.FacilityNDB{
+		FacilityWaypoint: types.FacilityWaypoint{FacilityAirport: types.FacilityAirport{Ident: ident, Region: region}},
+		Frequency:        hz,
+	}}
+}
+
+func TestPutListAfterFetch(t *testing.T) {
+	tc := openTestCache(t, Options{NavaidTTL: time.Hour})
+	ctx := context.Background()
+
+	if _, err := tc.Navaid(ctx, "KLO", "LS"); err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+
+	// A later list page carries only the thin VOR fields
+	tc.clk.now = tc.clk.now.Add(30 * time.Minute)
+	if err := tc.PutList(&types.FacilityListData{VORs: []types.FacilityVOR{vorEntry("KLO", "LS", 114850000)}}); err != nil {
+		t.Fatalf("PutList: %v", err)
+	}
+
+	navaid, err := tc.Navaid(ctx, "KLO", "LS")
+	if err != nil {
+		t.Fatalf("lookup: %v", err)
+	}
+	if navaid.Name != "KLOTEN" || navaid.Type != 3 {
+		t.Errorf("navaid = %+v, list entry overwrote the fetched record", navaid)
+	}
+
+	// The list did not refresh the record's age, so it expires an hour after the fetch
+	tc.clk.now = tc.clk.now.Add(31 * time.Minute)
+	if _, err := tc.Navaid(ctx, "KLO", "LS"); err != nil {
+		t.Fatalf("lookup: %v", err)
+	}
+	if tc.src.requests != 2 {
+		t.Errorf("requests = %d, want 2 (refetch after TTL)", tc.src.requests)
+	}
+}
+
+func TestListEntryOffline(t *testing.T) {
+	tc := openTestCache(t, Options{})
+	tc.Attach(nil)
+
+	if err := tc.PutList(&types.FacilityListData{VORs: []types.FacilityVOR{vorEntry("TRA", "LS", 114300000)}}); err != nil {
+		t.Fatalf("PutList: %v", err)
+	}
+	navaid, err := tc.Navaid(context.Background(), "TRA", "")
+	if err != nil {
+		t.Fatalf("lookup: %v", err)
+	}
+	if navaid.MHz != 114.3 {
+		t.Errorf("MHz = %v, want 114.3", navaid.MHz)
+	}
+	if _, err := tc.Navaid(context.Background(), "XXX", ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown navaid err = %v, want ErrNotFound", err)
+	}
+}
+
+// airportList is an airport facility list around Zurich
+func airportList() *types.FacilityListData {
+	return &types.FacilityListData{
+		ListType: types.SIMCONNECT_FACILITY_LIST_TYPE_AIRPORT,
+		Airports: []types.FacilityAirport{
+			{Ident: "LSGG", Region: "LS", Latitude: 46.238, Longitude: 6.109, Altitude: 430},
+			{Ident: "LSZB", Region: "LS", Latitude: 46.914, Longitude: 7.499, Altitude: 510},
+			{Ident: "LFSB", Region: "LF", Latitude: 47.590, Longitude: 7.529, Altitude: 269},
+			{Ident: "LSZR", Region: "LS", Latitude: 47.485, Longitude: 9.561, Altitude: 398},
+		},
+	}
+}
+
+func idents(airports []types.FacilityAirport) string {
+	var out []string
+	for _, a := range airports {
+		out = append(out, a.Ident)
+	}
+	return strings.Join(out, ",")
+}
+
+func TestNearest(t *testing.T) {
+	tc := openTestCache(t, Options{})
+	if err := tc.PutList(airportList()); err != nil {
+		t.Fatalf("PutList: %v", err)
+	}
+	// LSZH is only known as a full record, not from the list
+	if _, err := tc.Airport(context.Background(), "LSZH"); err != nil {
+		t.Fatalf("Airport: %v", err)
+	}
+
+	// From Zurich city
+	const lat, lon = 47.3769, 8.5417
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{3, "LSZH,LSZR,LFSB"},
+		{10, "LSZH,LSZR,LFSB,LSZB,LSGG"},
+		{-1, "LSZH,LSZR,LFSB,LSZB,LSGG"},
+		{0, ""},
+	}
+	for _, tt := range tests {
+		if got := idents(tc.Nearest(lat, lon, tt.n)); got != tt.want {
+			t.Errorf("Nearest(n=%d) = %s, want %s", tt.n, got, tt.want)
+		}
+	}
+
+	// A list entry for an airport with a full record is not returned twice
+	if err := tc.PutList(&types.FacilityListData{Airports: []types.FacilityAirport{{Ident: "LSZH", Region: "LS", Latitude: 47.458, Longitude: 8.548}}}); err != nil {
+		t.Fatalf("PutList: %v", err)
+	}
+	if got := idents(tc.Nearest(lat, lon, 2)); got != "LSZH,LSZR" {
+		t.Errorf("Nearest after list entry = %s, want LSZH,LSZR", got)
+	}
+}
+
+func TestRefreshAirports(t *testing.T) {
+	tc := openTestCache(t, Options{ListTTL: time.Hour})
+	ctx := context.Background()
+
+	if !tc.AirportListStale() {
+		t.Error("empty list is not stale")
+	}
+	if _, err := tc.RefreshAirports(ctx); err == nil {
+		t.Error("RefreshAirports succeeded without a list")
+	}
+
+	tc.src.list = airportList()
+	n, err := tc.RefreshAirports(ctx)
+	if err != nil {
+		t.Fatalf("RefreshAirports: %v", err)
+	}
+	if n != 4 || len(tc.Nearest(0, 0, -1)) != 4 {
+		t.Errorf("refreshed %d airports, Nearest has %d, want 4", n, len(tc.Nearest(0, 0, -1)))
+	}
+	if tc.AirportListStale() {
+		t.Error("list stale right after refresh")
+	}
+	tc.clk.now = tc.clk.now.Add(61 * time.Minute)
+	if !tc.AirportListStale() {
+		t.Error("list not stale after ListTTL")
+	}
+
+	tc.Attach(nil)
+	if _, err := tc.RefreshAirports(ctx); err == nil {
+		t.Error("RefreshAirports succeeded without a source")
+	}
+}
+
+func TestReopen(t *testing.T) {
+	tc := openTestCache(t, Options{})
+	ctx := context.Background()
+
+	if _, err := tc.Airport(ctx, "LSZH"); err != nil {
+		t.Fatalf("Airport: %v", err)
+	}
+	if _, err := tc.Navaid(ctx, "KLO", "LS"); err != nil {
+		t.Fatalf("Navaid: %v", err)
+	}
+	if err := tc.PutList(airportList()); err != nil {
+		t.Fatalf("PutList: %v", err)
+	}
+	if err := tc.Remove(KindAirportEntry, "LSGG", "LS"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+
+	reopened := tc.reopen(t, Options{})
+	airport, err := reopened.Airport(ctx, "LSZH")
+	if err != nil || len(airport.Runways) != 2 {
+		t.Errorf("reopened airport = %+v, %v", airport, err)
+	}
+	if navaid, err := reopened.Navaid(ctx, "KLO", ""); err != nil || navaid.Name != "KLOTEN" {
+		t.Errorf("reopened navaid = %+v, %v", navaid, err)
+	}
+	// The tombstone keeps the removed entry gone
+	if got := idents(reopened.Nearest(47.3769, 8.5417, -1)); got != "LSZH,LSZR,LFSB,LSZB" {
+		t.Errorf("reopened Nearest = %s, want LSZH,LSZR,LFSB,LSZB", got)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	tc := openTestCache(t, Options{})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		tc.clk.now = tc.clk.now.Add(time.Hour)
+		if err := tc.PutList(airportList()); err != nil {
+			t.Fatalf("PutList: %v", err)
+		}
+	}
+	if _, err := tc.Navaid(ctx, "KLO", "LS"); err != nil {
+		t.Fatalf("Navaid: %v", err)
+	}
+	if err := tc.Remove(KindNavaid, "KLO", "LS"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if lines := countLines(t, tc.path); lines != 3*4+2 {
+		t.Fatalf("lines before Compact = %d, want %d", lines, 3*4+2)
+	}
+
+	if err := tc.Compact(); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	if lines := countLines(t, tc.path); lines != 4 {
+		t.Errorf("lines after Compact = %d, want 4", lines)
+	}
+
+	reopened := tc.reopen(t, Options{})
+	if got := len(reopened.Nearest(0, 0, -1)); got != 4 {
+		t.Errorf("reopened airports = %d, want 4", got)
+	}
+	if _, err := reopened.Navaid(ctx, "KLO", "LS"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("removed navaid err = %v, want ErrNotFound", err)
+	}
+}
+
+func countLines(t *testing.T, path string) int {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Count(string(data), "\n")
+}
+
+func TestOpenDamagedFile(t *testing.T) {
+	record := `{"key":"airport_entry:LS:LSZB","kind":"airport_entry","fetched_at":"2024-06-01T12:00:00Z","entry":{"ident":"LSZB","region":"LS","latitude":46.914,"longitude":7.499,"altitude":510}}`
+	partial := `{"key":"airport_entry:LS:LSGG","kind":"airp`
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"partial last line", record + "\n" + partial, false},
+		{"partial last line and newline", record + "\n" + partial + "\n", false},
+		{"unterminated last line", record, false},
+		{"corrupt line in the middle", partial + "\n" + record + "\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "navdata.jsonl")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			cache, err := Open(Options{Path: path})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Open succeeded")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Open: %v", err)
+			}
+			if got := idents(cache.Nearest(0, 0, -1)); got != "LSZB" {
+				t.Errorf("airports = %s, want LSZB", got)
+			}
+
+			// Appends after the repair stay readable
+			if err := cache.PutList(&types.FacilityListData{Airports: []types.FacilityAirport{{Ident: "LSZR", Region: "LS"}}}); err != nil {
+				t.Fatalf("PutList: %v", err)
+			}
+			reopened, err := Open(Options{Path: path})
+			if err != nil {
+				t.Fatalf("reopen: %v", err)
+			}
+			if got := idents(reopened.Nearest(0, 0, -1)); got != "LSZB,LSZR" && got != "LSZR,LSZB" {
+				t.Errorf("reopened airports = %s, want LSZB and LSZR", got)
+			}
+		})
+	}
+}
+
+func TestPutResult(t *testing.T) {
+	tc := openTestCache(t, Options{})
+	tc.Attach(nil)
+
+	records, err := loadRecording("KLO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := facilities.NewAssembler(facilities.NavaidDefinition())
+	for i := range records {
+		if _, err := a.Add(&records[i]); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	if err := tc.PutResult(&facilities.Result{RequestID: records[0].RequestID, Root: a.Root()}); err != nil {
+		t.Fatalf("PutResult: %v", err)
+	}
+	if navaid, err := tc.Navaid(context.Background(), "KLO", "LS"); err != nil || navaid.Latitude != 47.541 {
+		t.Errorf("navaid = %+v, %v", navaid, err)
+	}
+}
@@ -0,0 +1,289 @@
+package navdata
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mycrew-online/sdk/pkg/facilities"
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// Record kinds stored in the cache file
+const (
+	KindAirport      = "airport"       // Full airport with runways, frequencies and parking
+	KindNavaid       = "navaid"        // VOR / ILS / DME
+	KindAirportEntry = "airport_entry" // Airport position from a facility list
+	KindNDB          = "ndb"           // NDB position and frequency from a facility list
+	KindWaypoint     = "waypoint"      // Waypoint position from a facility list
+	KindVOREntry     = "vor_entry"     // VOR / ILS position and frequency from a facility list
+)
+
+// Record is one line of the cache file
+type Record struct {
+	Key       string                  `json:"key"`
+	Kind      string                  `json:"kind"`
+	FetchedAt time.Time               `json:"fetched_at"`
+	Airport   *facilities.Airport     `json:"airport,omitempty"`
+	Navaid    *facilities.Navaid      `json:"navaid,omitempty"`
+	Entry     *types.FacilityAirport  `json:"entry,omitempty"`
+	NDB       *types.FacilityNDB      `json:"ndb,omitempty"`
+	Waypoint  *types.FacilityWaypoint `json:"waypoint,omitempty"`
+	Deleted   bool                    `json:"deleted,omitempty"`
+}
+
+// ident returns the ICAO ident of the facility held by the record
+func (r *Record) ident() string {
+	switch {
+	case r.Airport != nil:
+		return r.Airport.ICAO
+	case r.Navaid != nil:
+		return r.Navaid.ICAO
+	case r.Entry != nil:
+		return r.Entry.Ident
+	case r.NDB != nil:
+		return r.NDB.Ident
+	case r.Waypoint != nil:
+		return r.Waypoint.Ident
+	}
+	return ""
+}
+
+// recordKey builds the lookup key of a facility: kind, ICAO region and ident
+func recordKey(kind, icao, region string) string {
+	return kind + ":" + strings.ToUpper(strings.TrimSpace(region)) + ":" + strings.ToUpper(strings.TrimSpace(icao))
+}
+
+// store is an append-only JSON-lines file with an in-memory index.
+// The last line written for a key wins; Compact rewrites the file with live records only.
+type store struct {
+	mu      sync.RWMutex
+	path    string
+	records map[string]*Record
+	idents  map[string]map[string]struct{} // kind:ident -> keys, for lookups without a region
+}
+
+// openStore loads all records from path, creating the file if needed. An empty path keeps the store in memory.
+func openStore(path string) (*store, error) {
+	s := &store{
+		path:    path,
+		records: make(map[string]*Record),
+		idents:  make(map[string]map[string]struct{}),
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_RDONLY|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open navdata cache: %w", err)
+	}
+	defer f.Close()
+
+	// A crash while appending can leave a partial last line: it is dropped and the file cut
+	// back to the last complete record. An undecodable line anywhere else is an error.
+	var (
+		r         = bufio.NewReader(f)
+		offset    int64 // Start of the current line
+		corrupt   error // Undecodable line, tolerated only if nothing follows it
+		corruptAt int64
+		unended   bool // The last line has no newline, so appends would run into it
+	)
+	for line := 1; ; line++ {
+		data, readErr := r.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return nil, fmt.Errorf("failed to read navdata cache: %w", readErr)
+		}
+		if len(strings.TrimSpace(string(data))) > 0 {
+			if corrupt != nil {
+				return nil, corrupt
+			}
+			var rec Record
+			if err := json.Unmarshal(data, &rec); err != nil {
+				corrupt = fmt.Errorf("navdata cache %s line %d: %w", path, line, err)
+				corruptAt = offset
+			} else if rec.Deleted {
+				s.removeLocked(rec.Key)
+			} else {
+				s.setLocked(&rec)
+			}
+			unended = data[len(data)-1] != '\n'
+		}
+		offset += int64(len(data))
+		if readErr == io.EOF {
+			break
+		}
+	}
+	f.Close() // Before repairing, which reopens the file for writing
+
+	switch {
+	case corrupt != nil:
+		if err := os.Truncate(path, corruptAt); err != nil {
+			return nil, fmt.Errorf("failed to drop partial navdata cache line: %w", err)
+		}
+	case unended:
+		if err := appendNewline(path); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// appendNewline terminates a last line that was written without its newline
+func appendNewline(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open navdata cache: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write([]byte{'\n'}); err != nil {
+		return fmt.Errorf("failed to write navdata cache: %w", err)
+	}
+	return nil
+}
+
+// get returns the record stored under key
+func (s *store) get(key string) (*Record, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rec, ok := s.records[key]
+	return rec, ok
+}
+
+// put stores records in memory and appends them to the file
+func (s *store) put(records ...*Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, rec := range records {
+		s.setLocked(rec)
+	}
+	return s.appendLocked(records)
+}
+
+// remove drops a record and appends a tombstone so it stays gone after reopening
+func (s *store) remove(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.records[key]; !ok {
+		return nil
+	}
+	s.removeLocked(key)
+	return s.appendLocked([]*Record{{Key: key, Deleted: true}})
+}
+
+// lookup returns the records of a kind with the given ident, in any region
+func (s *store) lookup(kind, ident string) []*Record {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []*Record
+	for key := range s.idents[identKey(kind, ident)] {
+		out = append(out, s.records[key])
+	}
+	return out
+}
+
+// setLocked indexes a record; the caller holds s.mu
+func (s *store) setLocked(rec *Record) {
+	s.removeLocked(rec.Key)
+	s.records[rec.Key] = rec
+
+	ik := identKey(rec.Kind, rec.ident())
+	if s.idents[ik] == nil {
+		s.idents[ik] = make(map[string]struct{})
+	}
+	s.idents[ik][rec.Key] = struct{}{}
+}
+
+// removeLocked drops a record from both indexes; the caller holds s.mu
+func (s *store) removeLocked(key string) {
+	old, ok := s.records[key]
+	if !ok {
+		return
+	}
+	delete(s.records, key)
+	ik := identKey(old.Kind, old.ident())
+	delete(s.idents[ik], key)
+	if len(s.idents[ik]) == 0 {
+		delete(s.idents, ik)
+	}
+}
+
+// identKey builds the secondary index key of a kind and ident
+func identKey(kind, ident string) string {
+	return kind + ":" + strings.ToUpper(strings.TrimSpace(ident))
+}
+
+// each calls fn for every record of the given kind
+func (s *store) each(kind string, fn func(*Record)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, rec := range s.records {
+		if rec.Kind == kind {
+			fn(rec)
+		}
+	}
+}
+
+// appendLocked writes records as JSON lines; the caller holds s.mu
+func (s *store) appendLocked(records []*Record) error {
+	if s.path == "" {
+		return nil
+	}
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open navdata cache: %w", err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, rec := range records {
+		if err := enc.Encode(rec); err != nil {
+			return fmt.Errorf("failed to write navdata cache: %w", err)
+		}
+	}
+	return w.Flush()
+}
+
+// compact rewrites the file with one line per live record
+func (s *store) compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.path == "" {
+		return nil
+	}
+	tmp := s.path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("failed to compact navdata cache: %w", err)
+	}
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, rec := range s.records {
+		if err := enc.Encode(rec); err != nil {
+			f.Close()
+			os.Remove(tmp)
+			return fmt.Errorf("failed to compact navdata cache: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("failed to compact navdata cache: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to compact navdata cache: %w", err)
+	}
+	return os.Rename(tmp, s.path)
+}
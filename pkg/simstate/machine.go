@@ -0,0 +1,263 @@
+package simstate
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+// Events lists the system events the state machine consumes
+var Events = []types.SystemEventName{
+	types.SIMCONNECT_SYSTEM_EVENT_SIM,
+	types.SIMCONNECT_SYSTEM_EVENT_SIM_START,
+	types.SIMCONNECT_SYSTEM_EVENT_SIM_STOP,
+	types.SIMCONNECT_SYSTEM_EVENT_PAUSE_EX1,
+	types.SIMCONNECT_SYSTEM_EVENT_CRASHED,
+	types.SIMCONNECT_SYSTEM_EVENT_CRASH_RESET,
+	types.SIMCONNECT_SYSTEM_EVENT_FLIGHT_LOADED,
+	types.SIMCONNECT_SYSTEM_EVENT_AIRCRAFT_LOADED,
+	types.SIMCONNECT_SYSTEM_EVENT_VIEW,
+}
+
+// Subscriber subscribes to catalogued system events; *client.Engine satisfies it
+type Subscriber interface {
+	SubscribeSystemEvent(name types.SystemEventName) (uint32, error)
+}
+
+// CameraSource registers and requests SimVars; *client.Engine satisfies it
+type CameraSource interface {
+	RegisterSimVarDefinition(defID uint32, varName string, units string, dataType types.SimConnectDataType) error
+	RequestSimVarDataPeriodic(defID uint32, requestID uint32, period types.SimConnectPeriod) error
+}
+
+// Snapshot is the complete view of the simulator at a point in time
+type Snapshot struct {
+	State        State            `json:"state"`
+	Since        time.Time        `json:"since"` // When State was entered
+	SimRunning   bool             `json:"sim_running"`
+	Pause        types.PauseState `json:"pause"`
+	Crashed      bool             `json:"crashed"`
+	Loading      bool             `json:"loading"`
+	Camera       int              `json:"camera"` // Last CAMERA STATE value, 0 when unknown
+	View         types.ViewState  `json:"view"`
+	FlightFile   string           `json:"flight_file"`
+	AircraftFile string           `json:"aircraft_file"`
+	AircraftAt   time.Time        `json:"aircraft_at"` // When AircraftFile last changed
+}
+
+// IsFlying reports whether the user is actually flying right now
+func (s Snapshot) IsFlying() bool {
+	return s.State == StateFlying
+}
+
+// Transition describes a state change
+type Transition struct {
+	From  State     `json:"from"`
+	To    State     `json:"to"`
+	Cause string    `json:"cause"` // System event name or "CAMERA STATE"
+	At    time.Time `json:"at"`
+}
+
+// Machine aggregates simulator inputs into a State. It is safe for concurrent use;
+// callbacks run on the goroutine that delivered the input, after internal locks are released.
+//
+// System events alone cannot tell the main menu from a loaded flight, so the machine also needs
+// the CAMERA STATE SimVar: call Subscribe and WatchCamera once connected, then pass every message
+// to Process and every SimVar value to HandleSimVar (or call SetCameraState directly).
+type Machine struct {
+	mu           sync.Mutex
+	snap         Snapshot
+	callbacks    []func(Transition, Snapshot)
+	now          func() time.Time
+	cameraDefine uint32 // Definition of CAMERA STATE given to WatchCamera
+	cameraWired  bool   // WatchCamera succeeded
+}
+
+// New creates a state machine in StateUnknown
+func New() *Machine {
+	m := &Machine{now: time.Now}
+	m.snap.Since = m.now()
+	return m
+}
+
+// Subscribe subscribes to every event in Events
+func (m *Machine) Subscribe(s Subscriber) error {
+	for _, name := range Events {
+		if _, err := s.SubscribeSystemEvent(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WatchCamera registers CAMERA STATE under defID and requests it every second with requestID.
+// The IDs must not be used by other definitions and requests of the connection.
+func (m *Machine) WatchCamera(s CameraSource, defID uint32, requestID uint32) error {
+	if err := s.RegisterSimVarDefinition(defID, "CAMERA STATE", "number", types.SIMCONNECT_DATATYPE_INT32); err != nil {
+		return err
+	}
+	if err := s.RequestSimVarDataPeriodic(defID, requestID, types.SIMCONNECT_PERIOD_SECOND); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.cameraDefine = defID
+	m.cameraWired = true
+	m.mu.Unlock()
+	return nil
+}
+
+// HandleSimVar applies a SimVar value if it belongs to the CAMERA STATE definition of WatchCamera
+// and reports whether it did; values of other definitions are ignored
+func (m *Machine) HandleSimVar(defID uint32, value any) bool {
+	m.mu.Lock()
+	ours := m.cameraWired && defID == m.cameraDefine
+	m.mu.Unlock()
+	if !ours {
+		return false
+	}
+
+	switch v := value.(type) {
+	case int32:
+		m.SetCameraState(int(v))
+	case int64:
+		m.SetCameraState(int(v))
+	case float64:
+		m.SetCameraState(int(v))
+	case float32:
+		m.SetCameraState(int(v))
+	default:
+		return false
+	}
+	return true
+}
+
+// OnTransition registers a callback invoked after every state change
+func (m *Machine) OnTransition(fn func(Transition, Snapshot)) {
+	m.mu.Lock()
+	m.callbacks = append(m.callbacks, fn)
+	m.mu.Unlock()
+}
+
+// Snapshot returns the current state and its inputs
+func (m *Machine) Snapshot() Snapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.snap
+}
+
+// State returns the current state
+func (m *Machine) State() State {
+	return m.Snapshot().State
+}
+
+// Process feeds a message from Listen() into the machine; messages without a system event are ignored
+func (m *Machine) Process(msg any) {
+	if event, ok := types.IsSystemEvent(msg); ok {
+		m.HandleSystemEvent(event)
+	}
+}
+
+// HandleSystemEvent applies a decoded system event
+func (m *Machine) HandleSystemEvent(event *types.SystemEvent) {
+	m.apply(string(event.Name), func(s *Snapshot) {
+		switch event.Name {
+		case types.SIMCONNECT_SYSTEM_EVENT_SIM:
+			running, _ := event.State()
+			s.SimRunning = running
+			if running {
+				s.Loading = false
+			}
+		case types.SIMCONNECT_SYSTEM_EVENT_SIM_START:
+			s.SimRunning = true
+			s.Loading = false
+		case types.SIMCONNECT_SYSTEM_EVENT_SIM_STOP:
+			s.SimRunning = false
+		case types.SIMCONNECT_SYSTEM_EVENT_PAUSE, types.SIMCONNECT_SYSTEM_EVENT_PAUSE_EX1,
+			types.SIMCONNECT_SYSTEM_EVENT_PAUSED, types.SIMCONNECT_SYSTEM_EVENT_UNPAUSED:
+			s.Pause, _ = event.PauseState()
+		case types.SIMCONNECT_SYSTEM_EVENT_CRASHED:
+			s.Crashed = true
+		case types.SIMCONNECT_SYSTEM_EVENT_CRASH_RESET:
+			s.Crashed = false
+		case types.SIMCONNECT_SYSTEM_EVENT_FLIGHT_LOADED:
+			if file, ok := event.Filename(); ok {
+				s.FlightFile = file.Filename
+			}
+			// A new flight resets everything tied to the previous one
+			s.Loading = true
+			s.Crashed = false
+			s.Pause = types.PAUSE_STATE_FLAG_OFF
+		case types.SIMCONNECT_SYSTEM_EVENT_AIRCRAFT_LOADED:
+			if file, ok := event.Filename(); ok {
+				s.AircraftFile = file.Filename
+				s.AircraftAt = m.now()
+			}
+		case types.SIMCONNECT_SYSTEM_EVENT_VIEW:
+			s.View, _ = event.ViewState()
+		}
+	})
+}
+
+// SetCameraState applies a CAMERA STATE SimVar value
+func (m *Machine) SetCameraState(camera int) {
+	m.apply("CAMERA STATE", func(s *Snapshot) {
+		s.Camera = camera
+		switch classifyCamera(camera) {
+		case cameraInFlight:
+			s.Loading = false
+		case cameraMenu:
+			s.Loading = false
+			s.Crashed = false
+		}
+	})
+}
+
+// apply mutates the inputs, recomputes the state and fires callbacks on change
+func (m *Machine) apply(cause string, mutate func(*Snapshot)) {
+	m.mu.Lock()
+	from := m.snap.State
+	mutate(&m.snap)
+	to := derive(m.snap)
+
+	if to == from {
+		m.mu.Unlock()
+		return
+	}
+
+	now := m.now()
+	m.snap.State = to
+	m.snap.Since = now
+	snap := m.snap
+	callbacks := append([]func(Transition, Snapshot){}, m.callbacks...)
+	m.mu.Unlock()
+
+	t := Transition{From: from, To: to, Cause: cause, At: now}
+	for _, fn := range callbacks {
+		fn(t, snap)
+	}
+}
+
+// derive computes the state from its inputs, most specific condition first
+func derive(s Snapshot) State {
+	camera := classifyCamera(s.Camera)
+
+	switch {
+	case camera == cameraMenu:
+		return StateMenu
+	case s.Loading || camera == cameraLoading:
+		return StateLoading
+	case s.Crashed:
+		return StateCrashed
+	case !s.SimRunning:
+		if camera == cameraUnknown && s.FlightFile == "" && s.AircraftFile == "" {
+			return StateUnknown
+		}
+		return StateMenu
+	case s.Pause.IsPaused():
+		return StatePaused
+	default:
+		return StateFlying
+	}
+}
@@ -0,0 +1,240 @@
+package simstate
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mycrew-online/sdk/pkg/types"
+)
+
+func TestDerive(t *testing.T) {
+	tests := []struct {
+		name string
+		snap Snapshot
+		want State
+	}{
+		{"nothing received", Snapshot{}, StateUnknown},
+		{"stopped with a flight file", Snapshot{FlightFile: `C:\flights\LSZH.FLT`}, StateMenu},
+		{"stopped with a known camera", Snapshot{Camera: CameraCockpit}, StateMenu},
+		{"world map while running", Snapshot{SimRunning: true, Camera: CameraWorldMap}, StateMenu},
+		{"hangar wins over a crash", Snapshot{SimRunning: true, Crashed: true, Camera: CameraHangar}, StateMenu},
+		{"waiting camera", Snapshot{SimRunning: true, Camera: CameraWaiting}, StateLoading},
+		{"flight loading", Snapshot{SimRunning: true, Loading: true, Camera: CameraCockpit}, StateLoading},
+		{"crashed", Snapshot{SimRunning: true, Crashed: true, Camera: CameraExternal}, StateCrashed},
+		{"full pause", Snapshot{SimRunning: true, Pause: types.PAUSE_STATE_FLAG_PAUSE}, StatePaused},
+		{"active pause", Snapshot{SimRunning: true, Pause: types.PAUSE_STATE_FLAG_ACTIVE_PAUSE}, StatePaused},
+		{"sim pause", Snapshot{SimRunning: true, Pause: types.PAUSE_STATE_FLAG_SIM_PAUSE, Camera: CameraCockpit}, StatePaused},
+		{"flying without camera", Snapshot{SimRunning: true}, StateFlying},
+		{"flying in the cockpit", Snapshot{SimRunning: true, Camera: CameraCockpit}, StateFlying},
+		{"flying with the drone", Snapshot{SimRunning: true, Camera: CameraDrone}, StateFlying},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := derive(tt.snap); got != tt.want {
+				t.Errorf("derive = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// systemEvent decodes a system event the way the client does; value replaces the decoded
+// value for events whose payload is a parsed message
+func systemEvent(name types.SystemEventName, data uint32, value any) *types.SystemEvent {
+	ev := types.DecodeSystemEvent(name, 1, data)
+	if value != nil {
+		ev.Value = value
+	}
+	return ev
+}
+
+func filename(path string) *types.FilenameEventData {
+	return &types.FilenameEventData{Filename: path}
+}
+
+// testMachine returns a machine on a settable clock that records its transitions
+func testMachine() (*Machine, *[]Transition, *time.Time) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	m := New()
+	m.now = func() time.Time { return now }
+
+	var transitions []Transition
+	m.OnTransition(func(tr Transition, snap Snapshot) {
+		transitions = append(transitions, tr)
+	})
+	return m, &transitions, &now
+}
+
+func TestTransitions(t *testing.T) {
+	m, transitions, _ := testMachine()
+
+	steps := []struct {
+		name  string
+		apply func()
+		want  State
+		cause string // Cause of the transition the step makes, "" when the state holds
+	}{
+		{"main menu", func() { m.SetCameraState(CameraWorldMap) }, StateMenu, "CAMERA STATE"},
+		{"flight chosen", func() {
+			m.HandleSystemEvent(systemEvent(types.SIMCONNECT_SYSTEM_EVENT_FLIGHT_LOADED, 0, filename(`C:\flights\LSZH.FLT`)))
+		}, StateMenu, ""},
+		{"loading screen", func() { m.SetCameraState(CameraWaiting) }, StateLoading, "CAMERA STATE"},
+		{"ready to fly", func() { m.SetCameraState(CameraCockpit) }, StateMenu, "CAMERA STATE"},
+		{"sim start", func() { m.HandleSystemEvent(systemEvent(types.SIMCONNECT_SYSTEM_EVENT_SIM_START, 0, nil)) }, StateFlying, "SimStart"},
+		{"active pause", func() { m.HandleSystemEvent(systemEvent(types.SIMCONNECT_SYSTEM_EVENT_PAUSE_EX1, 0x04, nil)) }, StatePaused, "Pause_EX1"},
+		{"sim pause added", func() { m.HandleSystemEvent(systemEvent(types.SIMCONNECT_SYSTEM_EVENT_PAUSE_EX1, 0x0C, nil)) }, StatePaused, ""},
+		{"unpaused", func() { m.HandleSystemEvent(systemEvent(types.SIMCONNECT_SYSTEM_EVENT_PAUSE_EX1, 0, nil)) }, StateFlying, "Pause_EX1"},
+		{"crash", func() { m.HandleSystemEvent(systemEvent(types.SIMCONNECT_SYSTEM_EVENT_CRASHED, 0, nil)) }, StateCrashed, "Crashed"},
+		{"crash reset", func() { m.HandleSystemEvent(systemEvent(types.SIMCONNECT_SYSTEM_EVENT_CRASH_RESET, 0, nil)) }, StateFlying, "CrashReset"},
+		{"aircraft changed in flight", func() {
+			m.HandleSystemEvent(systemEvent(types.SIMCONNECT_SYSTEM_EVENT_AIRCRAFT_LOADED, 0, filename(`C:\aircraft\A320.AIR`)))
+		}, StateFlying, ""},
+		{"external view", func() { m.SetCameraState(CameraExternal) }, StateFlying, ""},
+		{"sim stop", func() { m.HandleSystemEvent(systemEvent(types.SIMCONNECT_SYSTEM_EVENT_SIM_STOP, 0, nil)) }, StateMenu, "SimStop"},
+		{"back to the hangar", func() { m.SetCameraState(CameraHangar) }, StateMenu, ""},
+	}
+	for _, step := range steps {
+		before := len(*transitions)
+		step.apply()
+
+		if got := m.State(); got != step.want {
+			t.Fatalf("%s: state = %s, want %s", step.name, got, step.want)
+		}
+		made := (*transitions)[before:]
+		switch {
+		case step.cause == "" && len(made) != 0:
+			t.Errorf("%s: unexpected transitions %+v", step.name, made)
+		case step.cause != "" && (len(made) != 1 || made[0].Cause != step.cause || made[0].To != step.want):
+			t.Errorf("%s: transitions = %+v, want one to %s caused by %s", step.name, made, step.want, step.cause)
+		}
+	}
+
+	snap := m.Snapshot()
+	if snap.FlightFile != `C:\flights\LSZH.FLT` || snap.AircraftFile != `C:\aircraft\A320.AIR` {
+		t.Errorf("files = %q, %q", snap.FlightFile, snap.AircraftFile)
+	}
+}
+
+func TestFlightLoadedResets(t *testing.T) {
+	m, _, _ := testMachine()
+	m.SetCameraState(CameraCockpit)
+	m.HandleSystemEvent(systemEvent(types.SIMCONNECT_SYSTEM_EVENT_SIM_START, 0, nil))
+	m.HandleSystemEvent(systemEvent(types.SIMCONNECT_SYSTEM_EVENT_PAUSE_EX1, 0x01, nil))
+	m.HandleSystemEvent(systemEvent(types.SIMCONNECT_SYSTEM_EVENT_CRASHED, 0, nil))
+
+	// Loading another flight from the pause menu of a crashed one
+	m.HandleSystemEvent(systemEvent(types.SIMCONNECT_SYSTEM_EVENT_FLIGHT_LOADED, 0, filename(`C:\flights\KJFK.FLT`)))
+	snap := m.Snapshot()
+	if snap.State != StateLoading || snap.Crashed || snap.Pause.IsPaused() {
+		t.Fatalf("after FlightLoaded = %+v", snap)
+	}
+
+	// The Sim event ends the load like SimStart does
+	m.HandleSystemEvent(systemEvent(types.SIMCONNECT_SYSTEM_EVENT_SIM, 1, nil))
+	if got := m.State(); got != StateFlying {
+		t.Errorf("after Sim 1 = %s, want flying", got)
+	}
+}
+
+func TestCallbacks(t *testing.T) {
+	m, _, now := testMachine()
+	start := *now
+
+	var got []Snapshot
+	m.OnTransition(func(tr Transition, snap Snapshot) {
+		// Callbacks run without the lock held
+		if current := m.State(); current != tr.To {
+			t.Errorf("State() in callback = %s, want %s", current, tr.To)
+		}
+		if tr.At != snap.Since || snap.State != tr.To {
+			t.Errorf("transition %+v does not match snapshot %+v", tr, snap)
+		}
+		got = append(got, snap)
+	})
+
+	*now = start.Add(time.Minute)
+	m.SetCameraState(CameraCockpit)
+	m.SetCameraState(CameraExternal) // No change
+	*now = start.Add(2 * time.Minute)
+	m.Process(map[string]any{"system_event": systemEvent(types.SIMCONNECT_SYSTEM_EVENT_SIM_START, 0, nil)})
+	m.Process(map[string]any{"event": &types.EventData{}}) // Not a system event
+
+	if len(got) != 2 {
+		t.Fatalf("callbacks = %d, want 2", len(got))
+	}
+	if got[0].State != StateMenu || !got[0].Since.Equal(start.Add(time.Minute)) {
+		t.Errorf("first = %+v", got[0])
+	}
+	if got[1].State != StateFlying || got[1].Camera != CameraExternal || !got[1].IsFlying() {
+		t.Errorf("second = %+v", got[1])
+	}
+}
+
+// fakeEngine records the subscriptions and SimVar requests of a machine
+type fakeEngine struct {
+	subscribed []types.SystemEventName
+	vars       map[uint32]string
+	requests   map[uint32]uint32 // RequestID → DefineID
+	err        error
+}
+
+func (f *fakeEngine) SubscribeSystemEvent(name types.SystemEventName) (uint32, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	f.subscribed = append(f.subscribed, name)
+	return uint32(len(f.subscribed)), nil
+}
+
+func (f *fakeEngine) RegisterSimVarDefinition(defID uint32, varName string, units string, dataType types.SimConnectDataType) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.vars[defID] = varName
+	return nil
+}
+
+func (f *fakeEngine) RequestSimVarDataPeriodic(defID uint32, requestID uint32, period types.SimConnectPeriod) error {
+	f.requests[requestID] = defID
+	return nil
+}
+
+func TestSubscribeAndWatchCamera(t *testing.T) {
+	engine := &fakeEngine{vars: make(map[uint32]string), requests: make(map[uint32]uint32)}
+	m, _, _ := testMachine()
+
+	if err := m.Subscribe(engine); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	if len(engine.subscribed) != len(Events) {
+		t.Errorf("subscribed %v, want %v", engine.subscribed, Events)
+	}
+
+	if m.HandleSimVar(100, int32(CameraWorldMap)) {
+		t.Error("HandleSimVar applied a value before WatchCamera")
+	}
+	if err := m.WatchCamera(engine, 100, 200); err != nil {
+		t.Fatalf("WatchCamera: %v", err)
+	}
+	if engine.vars[100] != "CAMERA STATE" || engine.requests[200] != 100 {
+		t.Errorf("vars %v, requests %v", engine.vars, engine.requests)
+	}
+
+	if m.HandleSimVar(101, int32(CameraWorldMap)) {
+		t.Error("HandleSimVar applied a value of another definition")
+	}
+	if !m.HandleSimVar(100, int32(CameraWorldMap)) || m.State() != StateMenu {
+		t.Errorf("after camera %d: state %s", CameraWorldMap, m.State())
+	}
+	if !m.HandleSimVar(100, float64(CameraWaiting)) || m.State() != StateLoading {
+		t.Errorf("after camera %d: state %s", CameraWaiting, m.State())
+	}
+
+	failing := &fakeEngine{err: errors.New("not connected")}
+	if err := New().Subscribe(failing); err == nil {
+		t.Error("Subscribe succeeded on a failing engine")
+	}
+	if err := New().WatchCamera(failing, 100, 200); err == nil {
+		t.Error("WatchCamera succeeded on a failing engine")
+	}
+}
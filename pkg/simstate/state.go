@@ -0,0 +1,85 @@
+// Package simstate combines system events and the CAMERA STATE SimVar into a
+// single authoritative simulator state with transition callbacks.
+package simstate
+
+// State is the aggregated simulator state
+type State int
+
+const (
+	StateUnknown State = iota // Nothing received yet
+	StateMenu                 // Main menu, world map or hangar
+	StateLoading              // A flight or aircraft is loading
+	StateFlying               // The user is in control and the simulation is running
+	StatePaused               // In a flight with any pause active
+	StateCrashed              // The user aircraft crashed and the crash has not been reset
+)
+
+// String returns a readable name of the state
+func (s State) String() string {
+	switch s {
+	case StateMenu:
+		return "menu"
+	case StateLoading:
+		return "loading"
+	case StateFlying:
+		return "flying"
+	case StatePaused:
+		return "paused"
+	case StateCrashed:
+		return "crashed"
+	default:
+		return "unknown"
+	}
+}
+
+// InFlight reports whether a flight is loaded and on screen (flying, paused or crashed)
+func (s State) InFlight() bool {
+	return s == StateFlying || s == StatePaused || s == StateCrashed
+}
+
+// CameraState values of the CAMERA STATE SimVar used by the state machine
+const (
+	CameraCockpit       = 2
+	CameraExternal      = 3
+	CameraDrone         = 4
+	CameraFixedOnPlane  = 5
+	CameraEnvironment   = 6
+	CameraSixDOF        = 7
+	CameraGameplay      = 8
+	CameraShowcase      = 9
+	CameraDroneAircraft = 10
+	CameraWaiting       = 11
+	CameraWorldMap      = 12
+	CameraHangarRTC     = 13
+	CameraHangarCustom  = 14
+	CameraMenuRTC       = 15
+	CameraInGameRTC     = 16
+	CameraReplay        = 17
+	CameraDroneTopDown  = 19
+	CameraHangar        = 21
+	CameraGround        = 24
+	CameraFollowTraffic = 25
+)
+
+// cameraClass buckets CAMERA STATE values
+type cameraClass int
+
+const (
+	cameraUnknown cameraClass = iota
+	cameraInFlight
+	cameraLoading
+	cameraMenu
+)
+
+func classifyCamera(camera int) cameraClass {
+	switch camera {
+	case 0:
+		return cameraUnknown
+	case CameraWaiting:
+		return cameraLoading
+	case CameraWorldMap, CameraHangarRTC, CameraHangarCustom, CameraMenuRTC, CameraHangar:
+		return cameraMenu
+	default:
+		return cameraInFlight
+	}
+}
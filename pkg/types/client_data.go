@@ -0,0 +1,404 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// ClientDataPeriod is the SIMCONNECT_CLIENT_DATA_PERIOD passed to SimConnect_RequestClientData
+type ClientDataPeriod uint32
+
+// SIMCONNECT_CLIENT_DATA_PERIOD defines how often client data is sent
+const (
+	SIMCONNECT_CLIENT_DATA_PERIOD_NEVER        ClientDataPeriod = iota // Stop sending data
+	SIMCONNECT_CLIENT_DATA_PERIOD_ONCE                                 // Send data once only
+	SIMCONNECT_CLIENT_DATA_PERIOD_VISUAL_FRAME                         // Send data every visual frame
+	SIMCONNECT_CLIENT_DATA_PERIOD_ON_SET                               // Send data whenever the area is set
+	SIMCONNECT_CLIENT_DATA_PERIOD_SECOND                               // Send data once per second
+)
+
+// SIMCONNECT_CREATE_CLIENT_DATA_FLAG defines flags for SimConnect_CreateClientData
+const (
+	SIMCONNECT_CREATE_CLIENT_DATA_FLAG_DEFAULT   uint32 = 0
+	SIMCONNECT_CREATE_CLIENT_DATA_FLAG_READ_ONLY uint32 = 1 // Only the creating client may write the area
+)
+
+// SIMCONNECT_CLIENT_DATA_REQUEST_FLAG defines flags for SimConnect_RequestClientData
+const (
+	SIMCONNECT_CLIENT_DATA_REQUEST_FLAG_DEFAULT uint32 = 0
+	SIMCONNECT_CLIENT_DATA_REQUEST_FLAG_CHANGED uint32 = 1 // Only send when a value changed by more than its epsilon
+	SIMCONNECT_CLIENT_DATA_REQUEST_FLAG_TAGGED  uint32 = 2 // Send datum ID/value pairs
+)
+
+// SIMCONNECT_CLIENT_DATA_SET_FLAG defines flags for SimConnect_SetClientData
+const (
+	SIMCONNECT_CLIENT_DATA_SET_FLAG_DEFAULT uint32 = 0
+	SIMCONNECT_CLIENT_DATA_SET_FLAG_TAGGED  uint32 = 1 // Data is datum ID/value pairs
+)
+
+// SIMCONNECT_CLIENTDATAOFFSET_AUTO places a definition entry directly after the previous one
+const SIMCONNECT_CLIENTDATAOFFSET_AUTO uint32 = 0xFFFFFFFF
+
+// ClientDataType is the dwSizeOrType of a client data definition entry: a byte size,
+// or one of the negative SIMCONNECT_CLIENTDATATYPE values
+type ClientDataType int32
+
+// SIMCONNECT_CLIENTDATATYPE defines the typed client data entries
+const (
+	SIMCONNECT_CLIENTDATATYPE_INT8    ClientDataType = -1
+	SIMCONNECT_CLIENTDATATYPE_INT16   ClientDataType = -2
+	SIMCONNECT_CLIENTDATATYPE_INT32   ClientDataType = -3
+	SIMCONNECT_CLIENTDATATYPE_INT64   ClientDataType = -4
+	SIMCONNECT_CLIENTDATATYPE_FLOAT32 ClientDataType = -5
+	SIMCONNECT_CLIENTDATATYPE_FLOAT64 ClientDataType = -6
+)
+
+// Size returns the number of bytes an entry of this type occupies
+func (t ClientDataType) Size() uint32 {
+	switch t {
+	case SIMCONNECT_CLIENTDATATYPE_INT8:
+		return 1
+	case SIMCONNECT_CLIENTDATATYPE_INT16:
+		return 2
+	case SIMCONNECT_CLIENTDATATYPE_INT32, SIMCONNECT_CLIENTDATATYPE_FLOAT32:
+		return 4
+	case SIMCONNECT_CLIENTDATATYPE_INT64, SIMCONNECT_CLIENTDATATYPE_FLOAT64:
+		return 8
+	}
+	if t > 0 {
+		return uint32(t)
+	}
+	return 0
+}
+
+// ClientDataField is one entry of a client data definition
+type ClientDataField struct {
+	Name    string         `json:"name"`
+	Offset  uint32         `json:"offset"`  // Byte offset in the client data area
+	Type    ClientDataType `json:"type"`    // Typed entry or raw byte size
+	Epsilon float32        `json:"epsilon"` // Minimum change reported with SIMCONNECT_CLIENT_DATA_REQUEST_FLAG_CHANGED
+	String  bool           `json:"string"`  // Raw bytes decoded as a null-terminated string
+}
+
+// ClientDataDefinition describes the layout of a client data area, mirroring the
+// sequence of SimConnect_AddToClientDataDefinition calls. Entries follow each other
+// in the area unless At moves the offset; data sent and received through the
+// definition is always packed in declaration order. Builder methods record the
+// first error and can be chained freely.
+type ClientDataDefinition struct {
+	fields []ClientDataField
+	offset uint32
+	err    error
+}
+
+// NewClientDataDefinition creates an empty client data definition
+func NewClientDataDefinition() *ClientDataDefinition {
+	return &ClientDataDefinition{}
+}
+
+// At sets the offset of the next entry
+func (d *ClientDataDefinition) At(offset uint32) *ClientDataDefinition {
+	d.offset = offset
+	return d
+}
+
+// Int8 adds a signed 8-bit entry
+func (d *ClientDataDefinition) Int8(name string) *ClientDataDefinition {
+	return d.add(ClientDataField{Name: name, Type: SIMCONNECT_CLIENTDATATYPE_INT8})
+}
+
+// Int16 adds a signed 16-bit entry
+func (d *ClientDataDefinition) Int16(name string) *ClientDataDefinition {
+	return d.add(ClientDataField{Name: name, Type: SIMCONNECT_CLIENTDATATYPE_INT16})
+}
+
+// Int32 adds a signed 32-bit entry
+func (d *ClientDataDefinition) Int32(name string) *ClientDataDefinition {
+	return d.add(ClientDataField{Name: name, Type: SIMCONNECT_CLIENTDATATYPE_INT32})
+}
+
+// Int64 adds a signed 64-bit entry
+func (d *ClientDataDefinition) Int64(name string) *ClientDataDefinition {
+	return d.add(ClientDataField{Name: name, Type: SIMCONNECT_CLIENTDATATYPE_INT64})
+}
+
+// Float32 adds a 32-bit float entry; epsilon is the minimum change reported with the CHANGED flag
+func (d *ClientDataDefinition) Float32(name string, epsilon float32) *ClientDataDefinition {
+	return d.add(ClientDataField{Name: name, Type: SIMCONNECT_CLIENTDATATYPE_FLOAT32, Epsilon: epsilon})
+}
+
+// Float64 adds a 64-bit float entry; epsilon is the minimum change reported with the CHANGED flag
+func (d *ClientDataDefinition) Float64(name string, epsilon float32) *ClientDataDefinition {
+	return d.add(ClientDataField{Name: name, Type: SIMCONNECT_CLIENTDATATYPE_FLOAT64, Epsilon: epsilon})
+}
+
+// Bytes adds a raw entry of size bytes
+func (d *ClientDataDefinition) Bytes(name string, size uint32) *ClientDataDefinition {
+	return d.add(ClientDataField{Name: name, Type: ClientDataType(size)})
+}
+
+// String adds a fixed-size, null-terminated string entry
+func (d *ClientDataDefinition) String(name string, size uint32) *ClientDataDefinition {
+	return d.add(ClientDataField{Name: name, Type: ClientDataType(size), String: true})
+}
+
+func (d *ClientDataDefinition) add(f ClientDataField) *ClientDataDefinition {
+	if d.err != nil {
+		return d
+	}
+	if f.Type.Size() == 0 {
+		d.err = fmt.Errorf("invalid size for client data entry %q", f.Name)
+		return d
+	}
+	for _, existing := range d.fields {
+		if existing.Name == f.Name {
+			d.err = fmt.Errorf("duplicate client data entry %q", f.Name)
+			return d
+		}
+	}
+	f.Offset = d.offset
+	d.offset += f.Type.Size()
+	d.fields = append(d.fields, f)
+	return d
+}
+
+// Fields returns the entries of the definition; the index of an entry is its DatumID
+func (d *ClientDataDefinition) Fields() ([]ClientDataField, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	if len(d.fields) == 0 {
+		return nil, fmt.Errorf("client data definition is empty")
+	}
+	fields := make([]ClientDataField, len(d.fields))
+	copy(fields, d.fields)
+	return fields, nil
+}
+
+// Size returns the number of bytes of data sent or received through the definition
+func (d *ClientDataDefinition) Size() uint32 {
+	var size uint32
+	for _, f := range d.fields {
+		size += f.Type.Size()
+	}
+	return size
+}
+
+// Extent returns the area size needed to hold every entry at its offset
+func (d *ClientDataDefinition) Extent() uint32 {
+	var extent uint32
+	for _, f := range d.fields {
+		if end := f.Offset + f.Type.Size(); end > extent {
+			extent = end
+		}
+	}
+	return extent
+}
+
+// Err returns the first error recorded while building the definition
+func (d *ClientDataDefinition) Err() error {
+	return d.err
+}
+
+// Decode reads a data block received through the definition into a name → value map
+func (d *ClientDataDefinition) Decode(data []byte) (map[string]any, error) {
+	fields, err := d.Fields()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]any, len(fields))
+	var pos uint32
+	for _, f := range fields {
+		end := pos + f.Type.Size()
+		if int(end) > len(data) {
+			return nil, fmt.Errorf("client data too short for entry %q: need %d bytes, have %d", f.Name, end, len(data))
+		}
+		values[f.Name] = decodeClientDataValue(f, data[pos:end])
+		pos = end
+	}
+	return values, nil
+}
+
+// DecodeTagged reads a SIMCONNECT_CLIENT_DATA_REQUEST_FLAG_TAGGED block of DatumID/value pairs
+func (d *ClientDataDefinition) DecodeTagged(data []byte) (map[string]any, error) {
+	fields, err := d.Fields()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]any)
+	pos := 0
+	for pos+4 <= len(data) {
+		datumID := binary.LittleEndian.Uint32(data[pos:])
+		pos += 4
+		if int(datumID) >= len(fields) {
+			return values, fmt.Errorf("unknown client data datum ID %d", datumID)
+		}
+		f := fields[datumID]
+		end := pos + int(f.Type.Size())
+		if end > len(data) {
+			return values, fmt.Errorf("client data too short for entry %q", f.Name)
+		}
+		values[f.Name] = decodeClientDataValue(f, data[pos:end])
+		pos = end
+	}
+	return values, nil
+}
+
+// Encode writes name → value pairs into a data block to send through the definition.
+// Missing entries are left zero.
+func (d *ClientDataDefinition) Encode(values map[string]any) ([]byte, error) {
+	fields, err := d.Fields()
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, d.Size())
+	var pos uint32
+	for _, f := range fields {
+		end := pos + f.Type.Size()
+		if value, ok := values[f.Name]; ok {
+			if err := encodeClientDataValue(f, value, data[pos:end]); err != nil {
+				return nil, err
+			}
+		}
+		pos = end
+	}
+	return data, nil
+}
+
+func decodeClientDataValue(f ClientDataField, b []byte) any {
+	switch f.Type {
+	case SIMCONNECT_CLIENTDATATYPE_INT8:
+		return int8(b[0])
+	case SIMCONNECT_CLIENTDATATYPE_INT16:
+		return int16(binary.LittleEndian.Uint16(b))
+	case SIMCONNECT_CLIENTDATATYPE_INT32:
+		return int32(binary.LittleEndian.Uint32(b))
+	case SIMCONNECT_CLIENTDATATYPE_INT64:
+		return int64(binary.LittleEndian.Uint64(b))
+	case SIMCONNECT_CLIENTDATATYPE_FLOAT32:
+		return math.Float32frombits(binary.LittleEndian.Uint32(b))
+	case SIMCONNECT_CLIENTDATATYPE_FLOAT64:
+		return math.Float64frombits(binary.LittleEndian.Uint64(b))
+	}
+	if f.String {
+		if i := bytes.IndexByte(b, 0); i >= 0 {
+			b = b[:i]
+		}
+		return string(b)
+	}
+	out := make([]byte, len(b))
+	copy(out, b)
+	return out
+}
+
+func encodeClientDataValue(f ClientDataField, value any, b []byte) error {
+	switch f.Type {
+	case SIMCONNECT_CLIENTDATATYPE_FLOAT32, SIMCONNECT_CLIENTDATATYPE_FLOAT64:
+		v, ok := clientDataFloat(value)
+		if !ok {
+			return fmt.Errorf("cannot convert %T to float for client data entry %q", value, f.Name)
+		}
+		if f.Type == SIMCONNECT_CLIENTDATATYPE_FLOAT32 {
+			binary.LittleEndian.PutUint32(b, math.Float32bits(float32(v)))
+		} else {
+			binary.LittleEndian.PutUint64(b, math.Float64bits(v))
+		}
+		return nil
+	case SIMCONNECT_CLIENTDATATYPE_INT8, SIMCONNECT_CLIENTDATATYPE_INT16, SIMCONNECT_CLIENTDATATYPE_INT32, SIMCONNECT_CLIENTDATATYPE_INT64:
+		v, ok := clientDataInt(value)
+		if !ok {
+			return fmt.Errorf("cannot convert %T to integer for client data entry %q", value, f.Name)
+		}
+		switch len(b) {
+		case 1:
+			b[0] = byte(v)
+		case 2:
+			binary.LittleEndian.PutUint16(b, uint16(v))
+		case 4:
+			binary.LittleEndian.PutUint32(b, uint32(v))
+		case 8:
+			binary.LittleEndian.PutUint64(b, uint64(v))
+		}
+		return nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		if len(v) >= len(b) {
+			return fmt.Errorf("string too long for client data entry %q: %d bytes, max %d", f.Name, len(v), len(b)-1)
+		}
+		copy(b, v)
+	case []byte:
+		if len(v) > len(b) {
+			return fmt.Errorf("data too long for client data entry %q: %d bytes, max %d", f.Name, len(v), len(b))
+		}
+		copy(b, v)
+	default:
+		return fmt.Errorf("cannot convert %T to bytes for client data entry %q", value, f.Name)
+	}
+	return nil
+}
+
+func clientDataFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	}
+	if i, ok := clientDataInt(value); ok {
+		return float64(i), true
+	}
+	return 0, false
+}
+
+func clientDataInt(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint64:
+		return int64(v), true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// Bind decodes client data into a fixed-size struct (or pointer to one) whose fields
+// match the packed little-endian layout of the area, e.g. struct{ Value float64; Count int32 }
+func (c *ClientData) Bind(v any) error {
+	data, ok := c.Data.([]byte)
+	if !ok {
+		return fmt.Errorf("client data has no raw payload")
+	}
+	size := binary.Size(v)
+	if size < 0 {
+		return fmt.Errorf("cannot bind client data to %T: not a fixed-size type", v)
+	}
+	if size > len(data) {
+		return fmt.Errorf("client data too short for %T: need %d bytes, have %d", v, size, len(data))
+	}
+	return binary.Read(bytes.NewReader(data[:size]), binary.LittleEndian, v)
+}
@@ -0,0 +1,37 @@
+package types
+
+import "fmt"
+
+// VersionBase represents SIMCONNECT_VERSION_BASE_TYPE
+type VersionBase struct {
+	Major    uint16 `json:"major"`
+	Minor    uint16 `json:"minor"`
+	Revision uint16 `json:"revision"`
+	Build    uint16 `json:"build"`
+}
+
+func (v VersionBase) String() string {
+	return fmt.Sprintf("%d.%d.%d.%d", v.Major, v.Minor, v.Revision, v.Build)
+}
+
+// ControllerItem represents SIMCONNECT_CONTROLLER_ITEM, an attached input device
+type ControllerItem struct {
+	DeviceName      string      `json:"device_name"`
+	DeviceID        uint32      `json:"device_id"`    // Index used in input definitions, e.g. "joystick:<DeviceID>:button:3"
+	ProductID       uint32      `json:"product_id"`   // USB product ID
+	CompositeID     uint32      `json:"composite_id"` // Interface index of a composite USB device
+	HardwareVersion VersionBase `json:"hardware_version"`
+}
+
+// ControllerList is one page of SIMCONNECT_RECV_CONTROLLERS_LIST
+type ControllerList struct {
+	RequestID   uint32           `json:"request_id"`
+	EntryNumber uint32           `json:"entry_number"` // Index of this page
+	OutOf       uint32           `json:"out_of"`       // Total number of pages
+	Controllers []ControllerItem `json:"controllers"`
+}
+
+// IsLastPage reports whether this page completes the enumeration
+func (l *ControllerList) IsLastPage() bool {
+	return l.OutOf == 0 || l.EntryNumber+1 >= l.OutOf
+}
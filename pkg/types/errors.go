@@ -1,7 +1,57 @@
 package types
 
+import "fmt"
+
 type SimConnectException uint32
 
+// Error returns the exception name, so a code can be matched with errors.Is
+func (code SimConnectException) Error() string {
+	return GetExceptionName(code)
+}
+
+// ExceptionSeverity classifies exceptions; levels are ordered, so severity >= ExceptionSeverityError
+// selects errors and critical exceptions
+type ExceptionSeverity int
+
+const (
+	ExceptionSeverityInfo     ExceptionSeverity = iota // No error
+	ExceptionSeverityWarning                           // Limit reached or duplicate request, the connection is unaffected
+	ExceptionSeverityError                             // The request failed
+	ExceptionSeverityCritical                          // The connection itself is unusable
+)
+
+// String returns a readable name of the severity
+func (s ExceptionSeverity) String() string {
+	switch s {
+	case ExceptionSeverityInfo:
+		return "info"
+	case ExceptionSeverityWarning:
+		return "warning"
+	case ExceptionSeverityError:
+		return "error"
+	case ExceptionSeverityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText encodes the severity by name, keeping JSON output readable
+func (s ExceptionSeverity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a severity name written by MarshalText
+func (s *ExceptionSeverity) UnmarshalText(text []byte) error {
+	for level := ExceptionSeverityInfo; level <= ExceptionSeverityCritical; level++ {
+		if string(text) == level.String() {
+			*s = level
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown exception severity %q", text)
+}
+
 // SIMCONNECT_EXCEPTION defines all possible exception codes returned by SimConnect
 const (
 	SIMCONNECT_EXCEPTION_NONE                              SimConnectException = iota // No error
@@ -243,19 +293,19 @@ func GetExceptionDescription(code SimConnectException) string {
 }
 
 // GetExceptionSeverity returns the severity level for an exception code
-func GetExceptionSeverity(code SimConnectException) string {
+func GetExceptionSeverity(code SimConnectException) ExceptionSeverity {
 	switch code {
 	case SIMCONNECT_EXCEPTION_NONE:
-		return "info"
+		return ExceptionSeverityInfo
 	case SIMCONNECT_EXCEPTION_UNOPENED, SIMCONNECT_EXCEPTION_VERSION_MISMATCH:
-		return "critical"
+		return ExceptionSeverityCritical
 	case SIMCONNECT_EXCEPTION_TOO_MANY_GROUPS, SIMCONNECT_EXCEPTION_TOO_MANY_EVENT_NAMES,
 		SIMCONNECT_EXCEPTION_TOO_MANY_MAPS, SIMCONNECT_EXCEPTION_TOO_MANY_OBJECTS,
 		SIMCONNECT_EXCEPTION_TOO_MANY_REQUESTS, SIMCONNECT_EXCEPTION_ALREADY_SUBSCRIBED,
 		SIMCONNECT_EXCEPTION_ALREADY_CREATED, SIMCONNECT_EXCEPTION_DUPLICATE_ID:
-		return "warning"
+		return ExceptionSeverityWarning
 	default:
-		return "error"
+		return ExceptionSeverityError
 	}
 }
 
@@ -275,15 +325,15 @@ func IsException(msg any) (*ExceptionData, bool) {
 
 // IsCriticalException checks if an exception is critical severity
 func IsCriticalException(exception *ExceptionData) bool {
-	return exception.Severity == "critical"
+	return exception.Severity == ExceptionSeverityCritical
 }
 
 // IsErrorException checks if an exception is error severity
 func IsErrorException(exception *ExceptionData) bool {
-	return exception.Severity == "error"
+	return exception.Severity == ExceptionSeverityError
 }
 
 // IsWarningException checks if an exception is warning severity
 func IsWarningException(exception *ExceptionData) bool {
-	return exception.Severity == "warning"
+	return exception.Severity == ExceptionSeverityWarning
 }
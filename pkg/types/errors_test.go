@@ -0,0 +1,28 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestExceptionSeverityJSON(t *testing.T) {
+	for level := ExceptionSeverityInfo; level <= ExceptionSeverityCritical; level++ {
+		data, err := json.Marshal(level)
+		if err != nil {
+			t.Fatalf("Marshal(%v): %v", level, err)
+		}
+
+		var decoded ExceptionSeverity
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", data, err)
+		}
+		if decoded != level {
+			t.Errorf("roundtrip of %s = %v, want %v", data, decoded, level)
+		}
+	}
+
+	var decoded ExceptionSeverity
+	if err := json.Unmarshal([]byte(`"fatal"`), &decoded); err == nil {
+		t.Error("Unmarshal of an unknown severity succeeded")
+	}
+}
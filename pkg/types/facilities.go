@@ -0,0 +1,222 @@
+package types
+
+type FacilityDataType uint32
+
+// SIMCONNECT_FACILITY_DATA_TYPE identifies the kind of record carried by a FACILITY_DATA message
+const (
+	SIMCONNECT_FACILITY_DATA_AIRPORT             FacilityDataType = iota // Airport
+	SIMCONNECT_FACILITY_DATA_RUNWAY                                      // Runway
+	SIMCONNECT_FACILITY_DATA_START                                       // Start position
+	SIMCONNECT_FACILITY_DATA_FREQUENCY                                   // Radio frequency
+	SIMCONNECT_FACILITY_DATA_HELIPAD                                     // Helipad
+	SIMCONNECT_FACILITY_DATA_APPROACH                                    // Approach procedure
+	SIMCONNECT_FACILITY_DATA_APPROACH_TRANSITION                         // Approach transition
+	SIMCONNECT_FACILITY_DATA_APPROACH_LEG                                // Approach leg
+	SIMCONNECT_FACILITY_DATA_FINAL_APPROACH_LEG                          // Final approach leg
+	SIMCONNECT_FACILITY_DATA_MISSED_APPROACH_LEG                         // Missed approach leg
+	SIMCONNECT_FACILITY_DATA_DEPARTURE                                   // Departure procedure (SID)
+	SIMCONNECT_FACILITY_DATA_ARRIVAL                                     // Arrival procedure (STAR)
+	SIMCONNECT_FACILITY_DATA_RUNWAY_TRANSITION                           // Runway transition
+	SIMCONNECT_FACILITY_DATA_ENROUTE_TRANSITION                          // Enroute transition
+	SIMCONNECT_FACILITY_DATA_TAXI_POINT                                  // Taxi point
+	SIMCONNECT_FACILITY_DATA_TAXI_PARKING                                // Taxi parking (gate, ramp)
+	SIMCONNECT_FACILITY_DATA_TAXI_PATH                                   // Taxi path
+	SIMCONNECT_FACILITY_DATA_TAXI_NAME                                   // Taxiway name
+	SIMCONNECT_FACILITY_DATA_JETWAY                                      // Jetway
+	SIMCONNECT_FACILITY_DATA_VOR                                         // VOR / ILS
+	SIMCONNECT_FACILITY_DATA_NDB                                         // NDB
+	SIMCONNECT_FACILITY_DATA_WAYPOINT                                    // Waypoint
+	SIMCONNECT_FACILITY_DATA_ROUTE                                       // Airway route
+	SIMCONNECT_FACILITY_DATA_PAVEMENT                                    // Runway pavement (threshold, blastpad, overrun)
+	SIMCONNECT_FACILITY_DATA_APPROACH_LIGHTS                             // Approach lighting system
+	SIMCONNECT_FACILITY_DATA_VASI                                        // Visual approach slope indicator
+	SIMCONNECT_FACILITY_DATA_VDGS                                        // Visual docking guidance system
+	SIMCONNECT_FACILITY_DATA_HOLDING_PATTERN                             // Holding pattern
+	SIMCONNECT_FACILITY_DATA_TAXI_PATH_WING_TIP                          // Taxi path wing tip clearance
+)
+
+// GetFacilityDataTypeName returns the SimConnect scope name for a facility data type
+func GetFacilityDataTypeName(t FacilityDataType) string {
+	switch t {
+	case SIMCONNECT_FACILITY_DATA_AIRPORT:
+		return "AIRPORT"
+	case SIMCONNECT_FACILITY_DATA_RUNWAY:
+		return "RUNWAY"
+	case SIMCONNECT_FACILITY_DATA_START:
+		return "START"
+	case SIMCONNECT_FACILITY_DATA_FREQUENCY:
+		return "FREQUENCY"
+	case SIMCONNECT_FACILITY_DATA_HELIPAD:
+		return "HELIPAD"
+	case SIMCONNECT_FACILITY_DATA_APPROACH:
+		return "APPROACH"
+	case SIMCONNECT_FACILITY_DATA_APPROACH_TRANSITION:
+		return "APPROACH_TRANSITION"
+	case SIMCONNECT_FACILITY_DATA_APPROACH_LEG:
+		return "APPROACH_LEG"
+	case SIMCONNECT_FACILITY_DATA_FINAL_APPROACH_LEG:
+		return "FINAL_APPROACH_LEG"
+	case SIMCONNECT_FACILITY_DATA_MISSED_APPROACH_LEG:
+		return "MISSED_APPROACH_LEG"
+	case SIMCONNECT_FACILITY_DATA_DEPARTURE:
+		return "DEPARTURE"
+	case SIMCONNECT_FACILITY_DATA_ARRIVAL:
+		return "ARRIVAL"
+	case SIMCONNECT_FACILITY_DATA_RUNWAY_TRANSITION:
+		return "RUNWAY_TRANSITION"
+	case SIMCONNECT_FACILITY_DATA_ENROUTE_TRANSITION:
+		return "ENROUTE_TRANSITION"
+	case SIMCONNECT_FACILITY_DATA_TAXI_POINT:
+		return "TAXI_POINT"
+	case SIMCONNECT_FACILITY_DATA_TAXI_PARKING:
+		return "TAXI_PARKING"
+	case SIMCONNECT_FACILITY_DATA_TAXI_PATH:
+		return "TAXI_PATH"
+	case SIMCONNECT_FACILITY_DATA_TAXI_NAME:
+		return "TAXI_NAME"
+	case SIMCONNECT_FACILITY_DATA_JETWAY:
+		return "JETWAY"
+	case SIMCONNECT_FACILITY_DATA_VOR:
+		return "VOR"
+	case SIMCONNECT_FACILITY_DATA_NDB:
+		return "NDB"
+	case SIMCONNECT_FACILITY_DATA_WAYPOINT:
+		return "WAYPOINT"
+	case SIMCONNECT_FACILITY_DATA_ROUTE:
+		return "ROUTE"
+	case SIMCONNECT_FACILITY_DATA_PAVEMENT:
+		return "PAVEMENT"
+	case SIMCONNECT_FACILITY_DATA_APPROACH_LIGHTS:
+		return "APPROACH_LIGHTS"
+	case SIMCONNECT_FACILITY_DATA_VASI:
+		return "VASI"
+	case SIMCONNECT_FACILITY_DATA_VDGS:
+		return "VDGS"
+	case SIMCONNECT_FACILITY_DATA_HOLDING_PATTERN:
+		return "HOLDING_PATTERN"
+	case SIMCONNECT_FACILITY_DATA_TAXI_PATH_WING_TIP:
+		return "TAXI_PATH_WING_TIP"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// SIMCONNECT_RECV_FACILITY_DATA_END marks the end of a facility data request
+type SIMCONNECT_RECV_FACILITY_DATA_END struct {
+	SIMCONNECT_RECV        // Inherits from base structure
+	RequestId       uint32 // ID of the client defined request
+}
+
+// FacilityDataEnd represents a parsed FACILITY_DATA_END for channel messages
+type FacilityDataEnd struct {
+	RequestID uint32 `json:"request_id"` // ID of the completed request
+}
+
+type FacilityListType uint32
+
+// SIMCONNECT_FACILITY_LIST_TYPE selects which facilities a list request or subscription covers
+const (
+	SIMCONNECT_FACILITY_LIST_TYPE_AIRPORT  FacilityListType = iota // Airports
+	SIMCONNECT_FACILITY_LIST_TYPE_WAYPOINT                         // Waypoints
+	SIMCONNECT_FACILITY_LIST_TYPE_NDB                              // NDBs
+	SIMCONNECT_FACILITY_LIST_TYPE_VOR                              // VORs
+	SIMCONNECT_FACILITY_LIST_TYPE_COUNT                            // Number of list types (invalid as a request)
+)
+
+// SIMCONNECT_RECV_LIST_TEMPLATE is the common header of all paged list messages
+type SIMCONNECT_RECV_LIST_TEMPLATE struct {
+	SIMCONNECT_RECV        // Inherits from base structure
+	DwRequestID     uint32 // ID of the client defined request
+	DwArraySize     uint32 // Number of elements in this page
+	DwEntryNumber   uint32 // Index of this page (0-based)
+	DwOutOf         uint32 // Total number of pages
+}
+
+// FacilityAirport represents SIMCONNECT_DATA_FACILITY_AIRPORT
+type FacilityAirport struct {
+	Ident     string  `json:"ident"`     // Airport ICAO ident
+	Region    string  `json:"region"`    // ICAO region code
+	Latitude  float64 `json:"latitude"`  // Latitude in degrees
+	Longitude float64 `json:"longitude"` // Longitude in degrees
+	Altitude  float64 `json:"altitude"`  // Altitude in meters
+}
+
+// FacilityWaypoint represents SIMCONNECT_DATA_FACILITY_WAYPOINT
+type FacilityWaypoint struct {
+	FacilityAirport
+	MagVar float32 `json:"magvar"` // Magnetic variation in degrees
+}
+
+// FacilityNDB represents SIMCONNECT_DATA_FACILITY_NDB
+type FacilityNDB struct {
+	FacilityWaypoint
+	Frequency uint32 `json:"frequency"` // Frequency in Hz
+}
+
+// FacilityVOR represents SIMCONNECT_DATA_FACILITY_VOR
+type FacilityVOR struct {
+	FacilityNDB
+	Flags           uint32  `json:"flags"`             // SIMCONNECT_RECV_ID_VOR_LIST_HAS_* flags
+	Localizer       float32 `json:"localizer"`         // Localizer heading in degrees
+	GlideLat        float64 `json:"glide_lat"`         // Glide slope latitude
+	GlideLon        float64 `json:"glide_lon"`         // Glide slope longitude
+	GlideAlt        float64 `json:"glide_alt"`         // Glide slope altitude in meters
+	GlideSlopeAngle float32 `json:"glide_slope_angle"` // Glide slope angle in degrees
+}
+
+// SIMCONNECT_RECV_ID_VOR_LIST flags describe which parts of a VOR record are valid
+const (
+	SIMCONNECT_RECV_ID_VOR_LIST_HAS_NAV_SIGNAL  uint32 = 0x1 // Has a navigation signal
+	SIMCONNECT_RECV_ID_VOR_LIST_HAS_LOCALIZER   uint32 = 0x2 // Has a localizer
+	SIMCONNECT_RECV_ID_VOR_LIST_HAS_GLIDE_SLOPE uint32 = 0x4 // Has a glide slope
+	SIMCONNECT_RECV_ID_VOR_LIST_HAS_DME         uint32 = 0x8 // Has DME
+)
+
+// ICAO represents SIMCONNECT_ICAO, the full identity of a facility
+type ICAO struct {
+	Type    string `json:"type"`    // Facility type letter: A, W, N, V, ...
+	Ident   string `json:"ident"`   // Facility ident
+	Region  string `json:"region"`  // ICAO region code
+	Airport string `json:"airport"` // Owning airport, for terminal facilities
+}
+
+// FacilityMinimal represents SIMCONNECT_FACILITY_MINIMAL
+type FacilityMinimal struct {
+	ICAO ICAO      `json:"icao"`
+	LLA  LatLonAlt `json:"lla"`
+}
+
+// FacilityListData represents one page of a facility list for channel messages.
+// Only the slice matching the list type is populated.
+type FacilityListData struct {
+	RequestID   uint32             `json:"request_id"`   // ID of the original request
+	ListType    FacilityListType   `json:"list_type"`    // Type of facilities in the list
+	EntryNumber uint32             `json:"entry_number"` // Index of this page (0-based)
+	OutOf       uint32             `json:"out_of"`       // Total number of pages
+	Airports    []FacilityAirport  `json:"airports,omitempty"`
+	Waypoints   []FacilityWaypoint `json:"waypoints,omitempty"`
+	NDBs        []FacilityNDB      `json:"ndbs,omitempty"`
+	VORs        []FacilityVOR      `json:"vors,omitempty"`
+	Minimal     []FacilityMinimal  `json:"minimal,omitempty"` // FACILITY_MINIMAL_LIST replies (RequestFacilitiesList_EX1)
+}
+
+// IsLastPage reports whether this page completes the list
+func (l *FacilityListData) IsLastPage() bool {
+	return l.EntryNumber+1 >= l.OutOf
+}
+
+// Len returns the number of records in the page
+func (l *FacilityListData) Len() int {
+	return len(l.Airports) + len(l.Waypoints) + len(l.NDBs) + len(l.VORs) + len(l.Minimal)
+}
+
+// Append merges the records of another page into this one
+func (l *FacilityListData) Append(page *FacilityListData) {
+	l.Airports = append(l.Airports, page.Airports...)
+	l.Waypoints = append(l.Waypoints, page.Waypoints...)
+	l.NDBs = append(l.NDBs, page.NDBs...)
+	l.VORs = append(l.VORs, page.VORs...)
+	l.Minimal = append(l.Minimal, page.Minimal...)
+	l.EntryNumber = page.EntryNumber
+	l.OutOf = page.OutOf
+}
@@ -0,0 +1,99 @@
+package types
+
+// FlowEventID represents SIMCONNECT_FLOW_EVENT_ID (MSFS 2024)
+type FlowEventID uint32
+
+const (
+	SIMCONNECT_FLOW_EVENT_NONE                FlowEventID = iota // No flow event
+	SIMCONNECT_FLOW_EVENT_FLT_LOAD                               // A flight file starts loading
+	SIMCONNECT_FLOW_EVENT_FLT_LOADED                             // A flight file finished loading
+	SIMCONNECT_FLOW_EVENT_TELEPORT_START                         // The user aircraft starts being teleported
+	SIMCONNECT_FLOW_EVENT_TELEPORT_DONE                          // Teleport finished
+	SIMCONNECT_FLOW_EVENT_BACK_ON_TRACK_START                    // Back on track starts
+	SIMCONNECT_FLOW_EVENT_BACK_ON_TRACK_DONE                     // Back on track finished
+	SIMCONNECT_FLOW_EVENT_SKIP_START                             // An activity skip starts
+	SIMCONNECT_FLOW_EVENT_SKIP_DONE                              // Activity skip finished
+	SIMCONNECT_FLOW_EVENT_BACK_TO_MAIN_MENU                      // The user returns to the main menu
+	SIMCONNECT_FLOW_EVENT_RTC_START                              // Real-time cinematic starts
+	SIMCONNECT_FLOW_EVENT_RTC_END                                // Real-time cinematic ended
+	SIMCONNECT_FLOW_EVENT_REPLAY_START                           // Replay starts
+	SIMCONNECT_FLOW_EVENT_REPLAY_END                             // Replay ended
+	SIMCONNECT_FLOW_EVENT_FLIGHT_START                           // The user takes control of the flight
+	SIMCONNECT_FLOW_EVENT_FLIGHT_END                             // The flight ended
+	SIMCONNECT_FLOW_EVENT_PLANE_CRASH                            // The user aircraft crashed
+)
+
+func (id FlowEventID) String() string {
+	switch id {
+	case SIMCONNECT_FLOW_EVENT_NONE:
+		return "NONE"
+	case SIMCONNECT_FLOW_EVENT_FLT_LOAD:
+		return "FLT_LOAD"
+	case SIMCONNECT_FLOW_EVENT_FLT_LOADED:
+		return "FLT_LOADED"
+	case SIMCONNECT_FLOW_EVENT_TELEPORT_START:
+		return "TELEPORT_START"
+	case SIMCONNECT_FLOW_EVENT_TELEPORT_DONE:
+		return "TELEPORT_DONE"
+	case SIMCONNECT_FLOW_EVENT_BACK_ON_TRACK_START:
+		return "BACK_ON_TRACK_START"
+	case SIMCONNECT_FLOW_EVENT_BACK_ON_TRACK_DONE:
+		return "BACK_ON_TRACK_DONE"
+	case SIMCONNECT_FLOW_EVENT_SKIP_START:
+		return "SKIP_START"
+	case SIMCONNECT_FLOW_EVENT_SKIP_DONE:
+		return "SKIP_DONE"
+	case SIMCONNECT_FLOW_EVENT_BACK_TO_MAIN_MENU:
+		return "BACK_TO_MAIN_MENU"
+	case SIMCONNECT_FLOW_EVENT_RTC_START:
+		return "RTC_START"
+	case SIMCONNECT_FLOW_EVENT_RTC_END:
+		return "RTC_END"
+	case SIMCONNECT_FLOW_EVENT_REPLAY_START:
+		return "REPLAY_START"
+	case SIMCONNECT_FLOW_EVENT_REPLAY_END:
+		return "REPLAY_END"
+	case SIMCONNECT_FLOW_EVENT_FLIGHT_START:
+		return "FLIGHT_START"
+	case SIMCONNECT_FLOW_EVENT_FLIGHT_END:
+		return "FLIGHT_END"
+	case SIMCONNECT_FLOW_EVENT_PLANE_CRASH:
+		return "PLANE_CRASH"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// SIMCONNECT_RECV_FLOW_EVENT represents a flow event received from SimConnect (MSFS 2024)
+type SIMCONNECT_RECV_FLOW_EVENT struct {
+	SIMCONNECT_RECV           // Inherits from base structure
+	FlowEvent       uint32    // SIMCONNECT_FLOW_EVENT_ID
+	SzFltPath       [260]byte // Flight file the event relates to
+}
+
+// FlowEvent represents a parsed flow event for channel messages
+type FlowEvent struct {
+	EventID FlowEventID `json:"event_id"`
+	Name    string      `json:"name"`     // EventID as text, e.g. "FLIGHT_START"
+	FltPath string      `json:"flt_path"` // Flight file the event relates to, may be empty
+}
+
+// EntersFlight reports whether the user takes control of a flight
+func (f *FlowEvent) EntersFlight() bool {
+	return f.EventID == SIMCONNECT_FLOW_EVENT_FLIGHT_START
+}
+
+// LeavesFlight reports whether the user's flight ended or was left for the main menu
+func (f *FlowEvent) LeavesFlight() bool {
+	return f.EventID == SIMCONNECT_FLOW_EVENT_FLIGHT_END || f.EventID == SIMCONNECT_FLOW_EVENT_BACK_TO_MAIN_MENU
+}
+
+// IsFlowEvent checks if a message carries a flow event and returns it
+func IsFlowEvent(msg any) (*FlowEvent, bool) {
+	if msgMap, ok := msg.(map[string]any); ok {
+		if event, ok := msgMap["flow_event"].(*FlowEvent); ok {
+			return event, true
+		}
+	}
+	return nil, false
+}
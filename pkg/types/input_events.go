@@ -0,0 +1,78 @@
+package types
+
+// InputEventType is the SIMCONNECT_INPUT_EVENT_TYPE of an input event value
+type InputEventType uint32
+
+// SIMCONNECT_INPUT_EVENT_TYPE defines how an input event value is encoded
+const (
+	SIMCONNECT_INPUT_EVENT_TYPE_DOUBLE InputEventType = iota // 64-bit float value
+	SIMCONNECT_INPUT_EVENT_TYPE_STRING                       // Null-terminated string value
+)
+
+// String returns the SimConnect name of the value type
+func (t InputEventType) String() string {
+	switch t {
+	case SIMCONNECT_INPUT_EVENT_TYPE_DOUBLE:
+		return "DOUBLE"
+	case SIMCONNECT_INPUT_EVENT_TYPE_STRING:
+		return "STRING"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// InputEventDescriptor represents SIMCONNECT_INPUT_EVENT_DESCRIPTOR
+type InputEventDescriptor struct {
+	Name string             `json:"name"` // Input event name, e.g. "AUTOPILOT_MASTER"
+	Hash uint64             `json:"hash"` // Hash used by Get/Set/Subscribe calls
+	Type SimConnectDataType `json:"type"` // SIMCONNECT_DATATYPE_FLOAT64 or a string type
+}
+
+// InputEventList is one page of SIMCONNECT_RECV_ENUMERATE_INPUT_EVENTS
+type InputEventList struct {
+	RequestID   uint32                 `json:"request_id"`
+	EntryNumber uint32                 `json:"entry_number"` // Index of this page
+	OutOf       uint32                 `json:"out_of"`       // Total number of pages
+	Events      []InputEventDescriptor `json:"events"`
+}
+
+// IsLastPage reports whether this page completes the enumeration
+func (l *InputEventList) IsLastPage() bool {
+	return l.OutOf == 0 || l.EntryNumber+1 >= l.OutOf
+}
+
+// InputEventValue is the value of an input event, from SIMCONNECT_RECV_GET_INPUT_EVENT
+// or as a change notification from SIMCONNECT_RECV_SUBSCRIBE_INPUT_EVENT
+type InputEventValue struct {
+	RequestID uint32         `json:"request_id,omitempty"` // Set for GET_INPUT_EVENT replies
+	Hash      uint64         `json:"hash,omitempty"`       // Set for SUBSCRIBE_INPUT_EVENT notifications
+	Name      string         `json:"name,omitempty"`       // Resolved from enumerated descriptors when known
+	Type      InputEventType `json:"type"`
+	Float     float64        `json:"float,omitempty"`  // Value when Type is DOUBLE
+	String    string         `json:"string,omitempty"` // Value when Type is STRING
+}
+
+// Value returns the float64 or string value according to Type
+func (v *InputEventValue) Value() any {
+	if v.Type == SIMCONNECT_INPUT_EVENT_TYPE_STRING {
+		return v.String
+	}
+	return v.Float
+}
+
+// InputEventParams represents SIMCONNECT_RECV_ENUMERATE_INPUT_EVENT_PARAMS
+type InputEventParams struct {
+	Hash   uint64   `json:"hash"`
+	Raw    string   `json:"raw"`    // Parameter list as sent by the simulator, e.g. "FLOAT64;STRING"
+	Params []string `json:"params"` // Raw split into one entry per parameter
+}
+
+// IsInputEvent checks if a message carries an input event value or change notification and returns it
+func IsInputEvent(msg any) (*InputEventValue, bool) {
+	if msgMap, ok := msg.(map[string]any); ok {
+		if value, ok := msgMap["input_event"].(*InputEventValue); ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
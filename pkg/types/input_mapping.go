@@ -0,0 +1,50 @@
+package types
+
+import "strings"
+
+// InputGroupID type for input group identifiers
+type InputGroupID uint32
+
+// InputBinding is a keyboard or joystick input mapped to SDK-allocated client events.
+// Input definitions use SimConnect syntax, e.g. "VK_LCONTROL+A", "joystick:0:button:3" or "joystick:0:XAxis".
+type InputBinding struct {
+	Input       string              `json:"input"`        // Input definition
+	GroupID     InputGroupID        `json:"group_id"`     // Input group the mapping belongs to
+	NotifyGroup NotificationGroupID `json:"notify_group"` // Notification group delivering the events
+	DownEventID ClientEventID       `json:"down_event"`   // Sent when the key or button is pressed, or the axis moves
+	UpEventID   ClientEventID       `json:"up_event"`     // Sent when the key or button is released; SIMCONNECT_UNUSED for axes
+}
+
+// IsAxis reports whether the input is a joystick axis, slider or POV, which only has a down event
+func (b *InputBinding) IsAxis() bool {
+	return IsInputAxis(b.Input)
+}
+
+// Pressed reports whether event is the down event of this binding
+func (b *InputBinding) Pressed(event *EventData) bool {
+	return event != nil && event.EventID == uint32(b.DownEventID)
+}
+
+// Released reports whether event is the up event of this binding
+func (b *InputBinding) Released(event *EventData) bool {
+	return event != nil && b.UpEventID != ClientEventID(SIMCONNECT_UNUSED) && event.EventID == uint32(b.UpEventID)
+}
+
+// Axis returns the axis position (-16383 to 16383) carried by an event of an axis binding
+func (b *InputBinding) Axis(event *EventData) (int32, bool) {
+	if !b.IsAxis() || !b.Pressed(event) {
+		return 0, false
+	}
+	return int32(event.EventData), true
+}
+
+// IsInputAxis reports whether an input definition names a joystick axis, slider or POV hat
+func IsInputAxis(input string) bool {
+	lower := strings.ToLower(input)
+	if !strings.HasPrefix(lower, "joystick:") {
+		return false
+	}
+	parts := strings.Split(lower, ":")
+	last := parts[len(parts)-1]
+	return strings.HasSuffix(last, "axis") || strings.Contains(lower, ":slider") || strings.Contains(lower, ":pov")
+}
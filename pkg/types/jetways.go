@@ -0,0 +1,72 @@
+package types
+
+// JetwayStatus represents SIMCONNECT_JETWAY_STATUS
+type JetwayStatus int32
+
+const (
+	SIMCONNECT_JETWAY_STATUS_REST             JetwayStatus = iota // Retracted at its home position
+	SIMCONNECT_JETWAY_STATUS_APPROACH_OUTSIDE                     // Moving towards the aircraft, outside of it
+	SIMCONNECT_JETWAY_STATUS_APPROACH_DOOR                        // Moving towards the door
+	SIMCONNECT_JETWAY_STATUS_HOOD_CONNECT                         // Lowering the hood onto the door
+	SIMCONNECT_JETWAY_STATUS_HOOD_DISCONNECT                      // Raising the hood from the door
+	SIMCONNECT_JETWAY_STATUS_RETRACT_OUTSIDE                      // Moving away from the aircraft, outside of it
+	SIMCONNECT_JETWAY_STATUS_RETRACT_HOME                         // Moving back to its home position
+	SIMCONNECT_JETWAY_STATUS_FULLY_ATTACHED                       // Connected to the door
+)
+
+func (s JetwayStatus) String() string {
+	switch s {
+	case SIMCONNECT_JETWAY_STATUS_REST:
+		return "REST"
+	case SIMCONNECT_JETWAY_STATUS_APPROACH_OUTSIDE:
+		return "APPROACH_OUTSIDE"
+	case SIMCONNECT_JETWAY_STATUS_APPROACH_DOOR:
+		return "APPROACH_DOOR"
+	case SIMCONNECT_JETWAY_STATUS_HOOD_CONNECT:
+		return "HOOD_CONNECT"
+	case SIMCONNECT_JETWAY_STATUS_HOOD_DISCONNECT:
+		return "HOOD_DISCONNECT"
+	case SIMCONNECT_JETWAY_STATUS_RETRACT_OUTSIDE:
+		return "RETRACT_OUTSIDE"
+	case SIMCONNECT_JETWAY_STATUS_RETRACT_HOME:
+		return "RETRACT_HOME"
+	case SIMCONNECT_JETWAY_STATUS_FULLY_ATTACHED:
+		return "FULLY_ATTACHED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// JetwayData represents SIMCONNECT_JETWAY_DATA, the state of one jetway
+type JetwayData struct {
+	AirportICAO         string       `json:"airport_icao"`
+	ParkingIndex        int32        `json:"parking_index"` // Index of the parking spot the jetway serves
+	Position            LatLonAlt    `json:"position"`
+	Orientation         PBH          `json:"orientation"`
+	Status              JetwayStatus `json:"status"`
+	Door                int32        `json:"door"`                   // Index of the aircraft door the jetway targets
+	ExitDoorRelativePos XYZ          `json:"exit_door_relative_pos"` // Aircraft exit door, relative to the jetway
+	MainHandlePos       XYZ          `json:"main_handle_pos"`        // Relative to the jetway
+	SecondaryHandlePos  XYZ          `json:"secondary_handle_pos"`   // Relative to the jetway
+	WheelGroundLockPos  XYZ          `json:"wheel_ground_lock_pos"`  // Relative to the jetway
+	JetwayObjectID      uint32       `json:"jetway_object_id"`
+	AttachedObjectID    uint32       `json:"attached_object_id"` // Aircraft the jetway is attached to, 0 when none
+}
+
+// IsAttached reports whether the jetway is connected to an aircraft door
+func (j JetwayData) IsAttached() bool {
+	return j.Status == SIMCONNECT_JETWAY_STATUS_FULLY_ATTACHED
+}
+
+// JetwayList is one page of SIMCONNECT_RECV_JETWAY_DATA
+type JetwayList struct {
+	RequestID   uint32       `json:"request_id"`
+	EntryNumber uint32       `json:"entry_number"` // Index of this page
+	OutOf       uint32       `json:"out_of"`       // Total number of pages
+	Jetways     []JetwayData `json:"jetways"`
+}
+
+// IsLastPage reports whether this page completes the reply
+func (l *JetwayList) IsLastPage() bool {
+	return l.OutOf == 0 || l.EntryNumber+1 >= l.OutOf
+}
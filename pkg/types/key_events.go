@@ -0,0 +1,244 @@
+package types
+
+import "strings"
+
+// KEvent is a simulator key event name (the K: events) that can be mapped with
+// SimConnect_MapClientEventToSimEvent and transmitted with Engine.Send
+type KEvent string
+
+// Normalize strips an optional "K:" prefix and upper-cases the name
+func (k KEvent) Normalize() KEvent {
+	name := strings.TrimSpace(string(k))
+	if len(name) > 2 && strings.EqualFold(name[:2], "K:") {
+		name = name[2:]
+	}
+	return KEvent(strings.ToUpper(name))
+}
+
+// Autopilot
+const (
+	KEVENT_AP_MASTER                  KEvent = "AP_MASTER"
+	KEVENT_AUTOPILOT_ON               KEvent = "AUTOPILOT_ON"
+	KEVENT_AUTOPILOT_OFF              KEvent = "AUTOPILOT_OFF"
+	KEVENT_AUTOPILOT_DISENGAGE_TOGGLE KEvent = "AUTOPILOT_DISENGAGE_TOGGLE"
+	KEVENT_AP_ALT_HOLD                KEvent = "AP_ALT_HOLD"
+	KEVENT_AP_ALT_HOLD_ON             KEvent = "AP_ALT_HOLD_ON"
+	KEVENT_AP_ALT_HOLD_OFF            KEvent = "AP_ALT_HOLD_OFF"
+	KEVENT_AP_ALT_VAR_SET_ENGLISH     KEvent = "AP_ALT_VAR_SET_ENGLISH" // Feet
+	KEVENT_AP_ALT_VAR_SET_METRIC      KEvent = "AP_ALT_VAR_SET_METRIC"  // Meters
+	KEVENT_AP_ALT_VAR_INC             KEvent = "AP_ALT_VAR_INC"
+	KEVENT_AP_ALT_VAR_DEC             KEvent = "AP_ALT_VAR_DEC"
+	KEVENT_AP_HDG_HOLD                KEvent = "AP_HDG_HOLD"
+	KEVENT_AP_HDG_HOLD_ON             KEvent = "AP_HDG_HOLD_ON"
+	KEVENT_AP_HDG_HOLD_OFF            KEvent = "AP_HDG_HOLD_OFF"
+	KEVENT_HEADING_BUG_SET            KEvent = "HEADING_BUG_SET" // Degrees
+	KEVENT_HEADING_BUG_INC            KEvent = "HEADING_BUG_INC"
+	KEVENT_HEADING_BUG_DEC            KEvent = "HEADING_BUG_DEC"
+	KEVENT_AP_NAV1_HOLD               KEvent = "AP_NAV1_HOLD"
+	KEVENT_AP_NAV1_HOLD_ON            KEvent = "AP_NAV1_HOLD_ON"
+	KEVENT_AP_NAV1_HOLD_OFF           KEvent = "AP_NAV1_HOLD_OFF"
+	KEVENT_AP_APR_HOLD                KEvent = "AP_APR_HOLD"
+	KEVENT_AP_APR_HOLD_ON             KEvent = "AP_APR_HOLD_ON"
+	KEVENT_AP_APR_HOLD_OFF            KEvent = "AP_APR_HOLD_OFF"
+	KEVENT_AP_BC_HOLD                 KEvent = "AP_BC_HOLD"
+	KEVENT_AP_LOC_HOLD                KEvent = "AP_LOC_HOLD"
+	KEVENT_AP_PANEL_VS_HOLD           KEvent = "AP_PANEL_VS_HOLD"
+	KEVENT_AP_VS_VAR_SET_ENGLISH      KEvent = "AP_VS_VAR_SET_ENGLISH" // Feet per minute
+	KEVENT_AP_VS_VAR_INC              KEvent = "AP_VS_VAR_INC"
+	KEVENT_AP_VS_VAR_DEC              KEvent = "AP_VS_VAR_DEC"
+	KEVENT_AP_PANEL_SPEED_HOLD        KEvent = "AP_PANEL_SPEED_HOLD"
+	KEVENT_AP_SPD_VAR_SET             KEvent = "AP_SPD_VAR_SET" // Knots
+	KEVENT_AP_SPD_VAR_INC             KEvent = "AP_SPD_VAR_INC"
+	KEVENT_AP_SPD_VAR_DEC             KEvent = "AP_SPD_VAR_DEC"
+	KEVENT_AP_MACH_VAR_SET            KEvent = "AP_MACH_VAR_SET"
+	KEVENT_AP_FLIGHT_LEVEL_CHANGE     KEvent = "FLIGHT_LEVEL_CHANGE"
+	KEVENT_AP_N1_HOLD                 KEvent = "AP_N1_HOLD"
+	KEVENT_AP_ATT_HOLD                KEvent = "AP_ATT_HOLD"
+	KEVENT_AP_WING_LEVELER            KEvent = "AP_WING_LEVELER"
+	KEVENT_AP_PITCH_REF_INC_UP        KEvent = "AP_PITCH_REF_INC_UP"
+	KEVENT_AP_PITCH_REF_INC_DN        KEvent = "AP_PITCH_REF_INC_DN"
+	KEVENT_AP_MAX_BANK_INC            KEvent = "AP_MAX_BANK_INC"
+	KEVENT_AP_MAX_BANK_DEC            KEvent = "AP_MAX_BANK_DEC"
+	KEVENT_AP_YAW_DAMPER_TOGGLE       KEvent = "YAW_DAMPER_TOGGLE"
+	KEVENT_AUTO_THROTTLE_ARM          KEvent = "AUTO_THROTTLE_ARM"
+	KEVENT_AUTO_THROTTLE_TO_GA        KEvent = "AUTO_THROTTLE_TO_GA"
+	KEVENT_TOGGLE_FLIGHT_DIRECTOR     KEvent = "TOGGLE_FLIGHT_DIRECTOR"
+	KEVENT_TOGGLE_GPS_DRIVES_NAV1     KEvent = "TOGGLE_GPS_DRIVES_NAV1"
+)
+
+// Flight controls
+const (
+	KEVENT_AILERON_SET           KEvent = "AILERON_SET"  // -16383 to 16383
+	KEVENT_ELEVATOR_SET          KEvent = "ELEVATOR_SET" // -16383 to 16383
+	KEVENT_RUDDER_SET            KEvent = "RUDDER_SET"   // -16383 to 16383
+	KEVENT_AXIS_AILERONS_SET     KEvent = "AXIS_AILERONS_SET"
+	KEVENT_AXIS_ELEVATOR_SET     KEvent = "AXIS_ELEVATOR_SET"
+	KEVENT_AXIS_RUDDER_SET       KEvent = "AXIS_RUDDER_SET"
+	KEVENT_CENTER_AILER_RUDDER   KEvent = "CENTER_AILER_RUDDER"
+	KEVENT_ELEV_TRIM_UP          KEvent = "ELEV_TRIM_UP"
+	KEVENT_ELEV_TRIM_DN          KEvent = "ELEV_TRIM_DN"
+	KEVENT_ELEVATOR_TRIM_SET     KEvent = "ELEVATOR_TRIM_SET"
+	KEVENT_AILERON_TRIM_LEFT     KEvent = "AILERON_TRIM_LEFT"
+	KEVENT_AILERON_TRIM_RIGHT    KEvent = "AILERON_TRIM_RIGHT"
+	KEVENT_RUDDER_TRIM_LEFT      KEvent = "RUDDER_TRIM_LEFT"
+	KEVENT_RUDDER_TRIM_RIGHT     KEvent = "RUDDER_TRIM_RIGHT"
+	KEVENT_FLAPS_UP              KEvent = "FLAPS_UP"
+	KEVENT_FLAPS_DOWN            KEvent = "FLAPS_DOWN"
+	KEVENT_FLAPS_INCR            KEvent = "FLAPS_INCR"
+	KEVENT_FLAPS_DECR            KEvent = "FLAPS_DECR"
+	KEVENT_FLAPS_SET             KEvent = "FLAPS_SET" // 0 to 16383
+	KEVENT_SPOILERS_TOGGLE       KEvent = "SPOILERS_TOGGLE"
+	KEVENT_SPOILERS_ON           KEvent = "SPOILERS_ON"
+	KEVENT_SPOILERS_OFF          KEvent = "SPOILERS_OFF"
+	KEVENT_SPOILERS_ARM_TOGGLE   KEvent = "SPOILERS_ARM_TOGGLE"
+	KEVENT_SPOILERS_SET          KEvent = "SPOILERS_SET" // 0 to 16383
+	KEVENT_GEAR_TOGGLE           KEvent = "GEAR_TOGGLE"
+	KEVENT_GEAR_UP               KEvent = "GEAR_UP"
+	KEVENT_GEAR_DOWN             KEvent = "GEAR_DOWN"
+	KEVENT_GEAR_SET              KEvent = "GEAR_SET" // 0 = up, 1 = down
+	KEVENT_PARKING_BRAKES        KEvent = "PARKING_BRAKES"
+	KEVENT_PARKING_BRAKE_SET     KEvent = "PARKING_BRAKE_SET"
+	KEVENT_BRAKES                KEvent = "BRAKES"
+	KEVENT_BRAKES_LEFT           KEvent = "BRAKES_LEFT"
+	KEVENT_BRAKES_RIGHT          KEvent = "BRAKES_RIGHT"
+	KEVENT_AXIS_LEFT_BRAKE_SET   KEvent = "AXIS_LEFT_BRAKE_SET"
+	KEVENT_AXIS_RIGHT_BRAKE_SET  KEvent = "AXIS_RIGHT_BRAKE_SET"
+	KEVENT_TOGGLE_TAIL_HOOK      KEvent = "TOGGLE_TAIL_HOOK_HANDLE"
+	KEVENT_TOGGLE_WATER_RUDDER   KEvent = "TOGGLE_WATER_RUDDER"
+	KEVENT_TOGGLE_TAILWHEEL_LOCK KEvent = "TOGGLE_TAILWHEEL_LOCK"
+)
+
+// Engines
+const (
+	KEVENT_THROTTLE_FULL            KEvent = "THROTTLE_FULL"
+	KEVENT_THROTTLE_CUT             KEvent = "THROTTLE_CUT"
+	KEVENT_THROTTLE_INCR            KEvent = "THROTTLE_INCR"
+	KEVENT_THROTTLE_DECR            KEvent = "THROTTLE_DECR"
+	KEVENT_THROTTLE_SET             KEvent = "THROTTLE_SET" // 0 to 16383
+	KEVENT_THROTTLE1_SET            KEvent = "THROTTLE1_SET"
+	KEVENT_THROTTLE2_SET            KEvent = "THROTTLE2_SET"
+	KEVENT_THROTTLE3_SET            KEvent = "THROTTLE3_SET"
+	KEVENT_THROTTLE4_SET            KEvent = "THROTTLE4_SET"
+	KEVENT_AXIS_THROTTLE_SET        KEvent = "AXIS_THROTTLE_SET"
+	KEVENT_PROP_PITCH_SET           KEvent = "PROP_PITCH_SET"
+	KEVENT_PROP_PITCH_INCR          KEvent = "PROP_PITCH_INCR"
+	KEVENT_PROP_PITCH_DECR          KEvent = "PROP_PITCH_DECR"
+	KEVENT_MIXTURE_SET              KEvent = "MIXTURE_SET"
+	KEVENT_MIXTURE_RICH             KEvent = "MIXTURE_RICH"
+	KEVENT_MIXTURE_LEAN             KEvent = "MIXTURE_LEAN"
+	KEVENT_MIXTURE_INCR             KEvent = "MIXTURE_INCR"
+	KEVENT_MIXTURE_DECR             KEvent = "MIXTURE_DECR"
+	KEVENT_ENGINE_AUTO_START        KEvent = "ENGINE_AUTO_START"
+	KEVENT_ENGINE_AUTO_SHUTDOWN     KEvent = "ENGINE_AUTO_SHUTDOWN"
+	KEVENT_TOGGLE_STARTER1          KEvent = "TOGGLE_STARTER1"
+	KEVENT_TOGGLE_STARTER2          KEvent = "TOGGLE_STARTER2"
+	KEVENT_MAGNETO_OFF              KEvent = "MAGNETO_OFF"
+	KEVENT_MAGNETO_RIGHT            KEvent = "MAGNETO_RIGHT"
+	KEVENT_MAGNETO_LEFT             KEvent = "MAGNETO_LEFT"
+	KEVENT_MAGNETO_BOTH             KEvent = "MAGNETO_BOTH"
+	KEVENT_MAGNETO_START            KEvent = "MAGNETO_START"
+	KEVENT_TOGGLE_ENGINE1_FAILURE   KEvent = "TOGGLE_ENGINE1_FAILURE"
+	KEVENT_TOGGLE_ENGINE2_FAILURE   KEvent = "TOGGLE_ENGINE2_FAILURE"
+	KEVENT_TOGGLE_FUEL_VALVE_ALL    KEvent = "TOGGLE_FUEL_VALVE_ALL"
+	KEVENT_TOGGLE_ELECT_FUEL_PUMP   KEvent = "TOGGLE_ELECT_FUEL_PUMP"
+	KEVENT_FUEL_SELECTOR_ALL        KEvent = "FUEL_SELECTOR_ALL"
+	KEVENT_FUEL_SELECTOR_LEFT       KEvent = "FUEL_SELECTOR_LEFT"
+	KEVENT_FUEL_SELECTOR_RIGHT      KEvent = "FUEL_SELECTOR_RIGHT"
+	KEVENT_FUEL_SELECTOR_OFF        KEvent = "FUEL_SELECTOR_OFF"
+	KEVENT_FUEL_PUMP                KEvent = "FUEL_PUMP"
+	KEVENT_ANTI_ICE_TOGGLE          KEvent = "ANTI_ICE_TOGGLE"
+	KEVENT_TOGGLE_PROPELLER_DEICE   KEvent = "TOGGLE_PROPELLER_DEICE"
+	KEVENT_TOGGLE_ENGINE1_CARB_HEAT KEvent = "ANTI_ICE_TOGGLE_ENG1"
+	KEVENT_APU_STARTER              KEvent = "APU_STARTER"
+	KEVENT_APU_OFF_SWITCH           KEvent = "APU_OFF_SWITCH"
+	KEVENT_APU_GENERATOR_SWITCH_SET KEvent = "APU_GENERATOR_SWITCH_SET"
+)
+
+// Electrical and lights
+const (
+	KEVENT_TOGGLE_MASTER_BATTERY            KEvent = "TOGGLE_MASTER_BATTERY"
+	KEVENT_TOGGLE_MASTER_ALTERNATOR         KEvent = "TOGGLE_MASTER_ALTERNATOR"
+	KEVENT_TOGGLE_MASTER_BATTERY_ALTERNATOR KEvent = "TOGGLE_MASTER_BATTERY_ALTERNATOR"
+	KEVENT_TOGGLE_AVIONICS_MASTER           KEvent = "TOGGLE_AVIONICS_MASTER"
+	KEVENT_AVIONICS_MASTER_SET              KEvent = "AVIONICS_MASTER_SET"
+	KEVENT_TOGGLE_EXTERNAL_POWER            KEvent = "TOGGLE_EXTERNAL_POWER"
+	KEVENT_SET_EXTERNAL_POWER               KEvent = "SET_EXTERNAL_POWER"
+	KEVENT_ELECTRICAL_CIRCUIT_TOGGLE        KEvent = "ELECTRICAL_CIRCUIT_TOGGLE"
+	KEVENT_ALL_LIGHTS_TOGGLE                KEvent = "ALL_LIGHTS_TOGGLE"
+	KEVENT_LANDING_LIGHTS_TOGGLE            KEvent = "LANDING_LIGHTS_TOGGLE"
+	KEVENT_LANDING_LIGHTS_ON                KEvent = "LANDING_LIGHTS_ON"
+	KEVENT_LANDING_LIGHTS_OFF               KEvent = "LANDING_LIGHTS_OFF"
+	KEVENT_LANDING_LIGHTS_SET               KEvent = "LANDING_LIGHTS_SET"
+	KEVENT_STROBES_TOGGLE                   KEvent = "STROBES_TOGGLE"
+	KEVENT_STROBES_SET                      KEvent = "STROBES_SET"
+	KEVENT_TOGGLE_BEACON_LIGHTS             KEvent = "TOGGLE_BEACON_LIGHTS"
+	KEVENT_BEACON_LIGHTS_SET                KEvent = "BEACON_LIGHTS_SET"
+	KEVENT_TOGGLE_NAV_LIGHTS                KEvent = "TOGGLE_NAV_LIGHTS"
+	KEVENT_NAV_LIGHTS_SET                   KEvent = "NAV_LIGHTS_SET"
+	KEVENT_TOGGLE_TAXI_LIGHTS               KEvent = "TOGGLE_TAXI_LIGHTS"
+	KEVENT_TAXI_LIGHTS_SET                  KEvent = "TAXI_LIGHTS_SET"
+	KEVENT_TOGGLE_LOGO_LIGHTS               KEvent = "TOGGLE_LOGO_LIGHTS"
+	KEVENT_TOGGLE_WING_LIGHTS               KEvent = "TOGGLE_WING_LIGHTS"
+	KEVENT_TOGGLE_RECOGNITION_LIGHTS        KEvent = "TOGGLE_RECOGNITION_LIGHTS"
+	KEVENT_PANEL_LIGHTS_TOGGLE              KEvent = "PANEL_LIGHTS_TOGGLE"
+	KEVENT_TOGGLE_CABIN_LIGHTS              KEvent = "TOGGLE_CABIN_LIGHTS"
+	KEVENT_TOGGLE_PITOT_HEAT                KEvent = "PITOT_HEAT_TOGGLE"
+	KEVENT_PITOT_HEAT_SET                   KEvent = "PITOT_HEAT_SET"
+)
+
+// Radios and navigation
+const (
+	KEVENT_COM_RADIO_SET_HZ       KEvent = "COM_RADIO_SET_HZ" // Hz
+	KEVENT_COM_STBY_RADIO_SET_HZ  KEvent = "COM_STBY_RADIO_SET_HZ"
+	KEVENT_COM2_RADIO_SET_HZ      KEvent = "COM2_RADIO_SET_HZ"
+	KEVENT_COM2_STBY_RADIO_SET_HZ KEvent = "COM2_STBY_RADIO_SET_HZ"
+	KEVENT_COM_STBY_RADIO_SWAP    KEvent = "COM_STBY_RADIO_SWAP"
+	KEVENT_COM2_RADIO_SWAP        KEvent = "COM2_RADIO_SWAP"
+	KEVENT_NAV1_RADIO_SET_HZ      KEvent = "NAV1_RADIO_SET_HZ"
+	KEVENT_NAV1_STBY_SET_HZ       KEvent = "NAV1_STBY_SET_HZ"
+	KEVENT_NAV2_RADIO_SET_HZ      KEvent = "NAV2_RADIO_SET_HZ"
+	KEVENT_NAV2_STBY_SET_HZ       KEvent = "NAV2_STBY_SET_HZ"
+	KEVENT_NAV1_RADIO_SWAP        KEvent = "NAV1_RADIO_SWAP"
+	KEVENT_NAV2_RADIO_SWAP        KEvent = "NAV2_RADIO_SWAP"
+	KEVENT_ADF_SET                KEvent = "ADF_SET"
+	KEVENT_ADF_COMPLETE_SET       KEvent = "ADF_COMPLETE_SET"
+	KEVENT_XPNDR_SET              KEvent = "XPNDR_SET" // BCD16
+	KEVENT_XPNDR_IDENT_ON         KEvent = "XPNDR_IDENT_ON"
+	KEVENT_VOR1_SET               KEvent = "VOR1_SET" // OBS degrees
+	KEVENT_VOR2_SET               KEvent = "VOR2_SET"
+	KEVENT_KOHLSMAN_SET           KEvent = "KOHLSMAN_SET" // Millibars * 16
+	KEVENT_KOHLSMAN_INC           KEvent = "KOHLSMAN_INC"
+	KEVENT_KOHLSMAN_DEC           KEvent = "KOHLSMAN_DEC"
+	KEVENT_BAROMETRIC             KEvent = "BAROMETRIC"
+	KEVENT_BAROMETRIC_STD         KEvent = "BAROMETRIC_STD_PRESSURE"
+	KEVENT_HEADING_GYRO_SET       KEvent = "HEADING_GYRO_SET"
+	KEVENT_GYRO_DRIFT_SET         KEvent = "GYRO_DRIFT_SET"
+)
+
+// Simulation
+const (
+	KEVENT_PAUSE_TOGGLE                     KEvent = "PAUSE_TOGGLE"
+	KEVENT_PAUSE_ON                         KEvent = "PAUSE_ON"
+	KEVENT_PAUSE_OFF                        KEvent = "PAUSE_OFF"
+	KEVENT_PAUSE_SET                        KEvent = "PAUSE_SET"
+	KEVENT_SIM_RATE_INCR                    KEvent = "SIM_RATE_INCR"
+	KEVENT_SIM_RATE_DECR                    KEvent = "SIM_RATE_DECR"
+	KEVENT_SIM_RATE_SET                     KEvent = "SIM_RATE_SET"
+	KEVENT_SLEW_TOGGLE                      KEvent = "SLEW_TOGGLE"
+	KEVENT_SLEW_ON                          KEvent = "SLEW_ON"
+	KEVENT_SLEW_OFF                         KEvent = "SLEW_OFF"
+	KEVENT_FREEZE_LATITUDE_LONGITUDE_TOGGLE KEvent = "FREEZE_LATITUDE_LONGITUDE_TOGGLE"
+	KEVENT_FREEZE_ALTITUDE_TOGGLE           KEvent = "FREEZE_ALTITUDE_TOGGLE"
+	KEVENT_FREEZE_ATTITUDE_TOGGLE           KEvent = "FREEZE_ATTITUDE_TOGGLE"
+	KEVENT_SITUATION_RESET                  KEvent = "SITUATION_RESET"
+	KEVENT_REPAIR_AND_REFUEL                KEvent = "REPAIR_AND_REFUEL"
+	KEVENT_TOGGLE_AIRCRAFT_EXIT             KEvent = "TOGGLE_AIRCRAFT_EXIT"
+	KEVENT_TOGGLE_PUSHBACK                  KEvent = "TOGGLE_PUSHBACK"
+	KEVENT_KEY_TUG_HEADING                  KEvent = "KEY_TUG_HEADING"
+	KEVENT_SMOKE_TOGGLE                     KEvent = "SMOKE_TOGGLE"
+	KEVENT_TOGGLE_JETWAY                    KEvent = "TOGGLE_JETWAY"
+	KEVENT_REQUEST_FUEL_KEY                 KEvent = "REQUEST_FUEL_KEY"
+	KEVENT_ZOOM_IN                          KEvent = "ZOOM_IN"
+	KEVENT_ZOOM_OUT                         KEvent = "ZOOM_OUT"
+	KEVENT_VIEW_MODE                        KEvent = "VIEW_MODE"
+)
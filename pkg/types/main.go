@@ -39,6 +39,9 @@ const (
 	SIMCONNECT_OBJECT_ID_USER uint32 = 0 // User aircraft
 )
 
+// SIMCONNECT_UNUSED marks an optional ID or value as not used
+const SIMCONNECT_UNUSED uint32 = 0xFFFFFFFF
+
 // SIMCONNECT_DATA_REQUEST_FLAG defines data request flags
 const (
 	SIMCONNECT_DATA_REQUEST_FLAG_DEFAULT uint32 = 0 // Default request flags
@@ -72,6 +75,23 @@ type ClientEventID uint32
 // NotificationGroupID type for notification group identifiers
 type NotificationGroupID uint32
 
+// EventKind tells how a received event ID was registered with the SDK
+type EventKind string
+
+const (
+	EventKindSystem  EventKind = "system"  // Subscribed with SubscribeToSystemEvent
+	EventKindClient  EventKind = "client"  // Mapped with MapClientEventToSimEvent
+	EventKindInput   EventKind = "input"   // Mapped from a keyboard or joystick input
+	EventKindUnknown EventKind = "unknown" // Not registered through this Engine
+)
+
+// EventRegistration is a registry entry describing a client or system event ID
+type EventRegistration struct {
+	EventID uint32    `json:"event_id"`
+	Name    string    `json:"name"`
+	Kind    EventKind `json:"kind"`
+}
+
 // Complex SimConnect data structure definitions
 // These correspond to the SIMCONNECT_DATATYPE_* structure types
 
@@ -110,6 +130,18 @@ type Waypoint struct {
 	Throttle  float64 `json:"throttle"`  // Throttle percentage (0.0-1.0)
 }
 
+// SIMCONNECT_WAYPOINT_FLAGS defines the flags of a Waypoint
+const (
+	SIMCONNECT_WAYPOINT_NONE                   uint32 = 0x00
+	SIMCONNECT_WAYPOINT_SPEED_REQUESTED        uint32 = 0x04       // Use the Speed field
+	SIMCONNECT_WAYPOINT_THROTTLE_REQUESTED     uint32 = 0x08       // Use the Throttle field
+	SIMCONNECT_WAYPOINT_COMPUTE_VERTICAL_SPEED uint32 = 0x10       // Compute vertical speed to reach the altitude
+	SIMCONNECT_WAYPOINT_ALTITUDE_IS_AGL        uint32 = 0x20       // Altitude is above ground level
+	SIMCONNECT_WAYPOINT_ON_GROUND              uint32 = 0x00100000 // Place the object on the ground
+	SIMCONNECT_WAYPOINT_REVERSE                uint32 = 0x00200000 // Back up to this waypoint
+	SIMCONNECT_WAYPOINT_WRAP_TO_FIRST          uint32 = 0x00400000 // Continue with the first waypoint after the last
+)
+
 // LatLonAlt represents SIMCONNECT_DATA_LATLONALT structure
 type LatLonAlt struct {
 	Latitude  float64 `json:"latitude"`  // Latitude in degrees
@@ -123,3 +155,10 @@ type XYZ struct {
 	Y float64 `json:"y"` // Y coordinate
 	Z float64 `json:"z"` // Z coordinate
 }
+
+// PBH represents SIMCONNECT_DATA_PBH structure
+type PBH struct {
+	Pitch   float32 `json:"pitch"`   // Pitch in degrees
+	Bank    float32 `json:"bank"`    // Bank in degrees
+	Heading float32 `json:"heading"` // Heading in degrees
+}
@@ -30,12 +30,24 @@ type SIMCONNECT_RECV_EXCEPTION struct {
 
 // ExceptionData represents a parsed SimConnect exception for channel messages
 type ExceptionData struct {
-	ExceptionCode SimConnectException `json:"exception_code"` // Numeric exception code
-	ExceptionName string              `json:"exception_name"` // Human-readable exception name
-	Description   string              `json:"description"`    // Detailed description of the exception
-	SendID        uint32              `json:"send_id"`        // ID of the packet that caused the exception
-	Index         uint32              `json:"index"`          // Index number for some exceptions
-	Severity      string              `json:"severity"`       // "warning", "error", "critical"
+	ExceptionCode SimConnectException `json:"exception_code"`   // Numeric exception code
+	ExceptionName string              `json:"exception_name"`   // Human-readable exception name
+	Description   string              `json:"description"`      // Detailed description of the exception
+	SendID        uint32              `json:"send_id"`          // ID of the packet that caused the exception
+	Index         uint32              `json:"index"`            // Index number for some exceptions
+	Severity      ExceptionSeverity   `json:"severity"`         // Encoded as "info", "warning", "error" or "critical"
+	Packet        *PacketContext      `json:"packet,omitempty"` // Call that sent the packet, when still in the packet history
+	Field         string              `json:"field,omitempty"`  // Definition field added by the offending packet, when known
+}
+
+// PacketContext describes an outgoing SimConnect call, recorded by the ID of the packet it sent
+type PacketContext struct {
+	SendID   uint32 `json:"send_id"`         // Packet ID reported by SimConnect_GetLastSentPacketID
+	Function string `json:"function"`        // SimConnect function, e.g. "SimConnect_AddToDataDefinition"
+	Method   string `json:"method"`          // Engine method the application called, e.g. "RegisterSimVarDefinition"
+	Args     []any  `json:"args"`            // Arguments of the SimConnect call
+	Field    string `json:"field,omitempty"` // Definition field added by the packet, for the AddTo*Definition calls
+	Caller   string `json:"caller"`          // file:line of the first caller outside pkg/client
 }
 
 // SIMCONNECT_RECV_EVENT represents event information received from SimConnect
@@ -49,12 +61,13 @@ type SIMCONNECT_RECV_EVENT struct {
 
 // EventData represents a parsed SimConnect event for channel messages
 type EventData struct {
-	GroupID   uint32 `json:"group_id"`   // ID of the group the event belongs to
-	EventID   uint32 `json:"event_id"`   // ID of the event
-	EventData uint32 `json:"event_data"` // Event-specific data value
-	// This is not needed due to message type
-	//EventName string `json:"event_name"` // Human-readable event name (if available)
-	EventType SimConnectRecvID `json:"event_type"` // Type of event: "system", "client", or "unknown"
+	GroupID   uint32           `json:"group_id"`   // ID of the group the event belongs to
+	EventID   uint32           `json:"event_id"`   // ID of the event
+	EventData uint32           `json:"event_data"` // Event-specific data value
+	EventName string           `json:"event_name"` // Registered event name (empty if unknown)
+	GroupName string           `json:"group_name"` // Registered group name (empty if unnamed)
+	Kind      EventKind        `json:"kind"`       // How the event ID was registered: system, client, input or unknown
+	EventType SimConnectRecvID `json:"event_type"` // Receive ID the event arrived with
 }
 
 // SIMCONNECT_RECV_EVENT_EX1 represents extended event information received from SimConnect
@@ -71,9 +84,12 @@ type SIMCONNECT_RECV_EVENT_EX1 struct {
 
 // EventExData represents a parsed SimConnect extended event for channel messages
 type EventExData struct {
-	GroupID uint32   `json:"group_id"` // ID of the group the event belongs to
-	EventID uint32   `json:"event_id"` // ID of the event
-	Data    []uint32 `json:"data"`     // Array of event data parameters
+	GroupID   uint32    `json:"group_id"`   // ID of the group the event belongs to
+	EventID   uint32    `json:"event_id"`   // ID of the event
+	Data      []uint32  `json:"data"`       // Array of event data parameters
+	EventName string    `json:"event_name"` // Registered event name (empty if unknown)
+	GroupName string    `json:"group_name"` // Registered group name (empty if unnamed)
+	Kind      EventKind `json:"kind"`       // How the event ID was registered: system, client, input or unknown
 }
 
 // SIMCONNECT_RECV_SIMOBJECT_DATA_BYTYPE represents SimObject data by type received from SimConnect
@@ -106,6 +122,7 @@ type AssignedObjectData struct {
 type SIMCONNECT_RECV_CLIENT_DATA struct {
 	SIMCONNECT_RECV        // Inherits from base structure
 	DwRequestID     uint32 // ID of the client defined request
+	DwObjectID      uint32 // Object ID (unused for client data, same layout as SIMOBJECT_DATA)
 	DwDefineID      uint32 // ID of the client defined data definition
 	DwFlags         uint32 // Flags that were set for this data request
 	DwEntryNumber   uint32 // Index number of this data (1-based)
@@ -116,11 +133,23 @@ type SIMCONNECT_RECV_CLIENT_DATA struct {
 
 // ClientData represents parsed client data for channel messages
 type ClientData struct {
-	RequestID    uint32      `json:"request_id"`    // ID of the original request
-	DefineID     uint32      `json:"define_id"`     // ID of the data definition
-	EntryNumber  uint32      `json:"entry_number"`  // Index of this data entry
-	TotalEntries uint32      `json:"total_entries"` // Total number of entries
-	Data         interface{} `json:"data"`          // The actual data
+	RequestID    uint32         `json:"request_id"`       // ID of the original request
+	DefineID     uint32         `json:"define_id"`        // ID of the data definition
+	Flags        uint32         `json:"flags"`            // SIMCONNECT_CLIENT_DATA_REQUEST_FLAG values of the request
+	EntryNumber  uint32         `json:"entry_number"`     // Index of this data entry
+	TotalEntries uint32         `json:"total_entries"`    // Total number of entries
+	Data         interface{}    `json:"data"`             // The actual data ([]byte)
+	Values       map[string]any `json:"values,omitempty"` // Decoded entries when the definition was registered
+}
+
+// IsClientData checks if a message carries client data and returns it
+func IsClientData(msg any) (*ClientData, bool) {
+	if msgMap, ok := msg.(map[string]any); ok {
+		if data, ok := msgMap["client_data"].(*ClientData); ok {
+			return data, true
+		}
+	}
+	return nil, false
 }
 
 // SIMCONNECT_RECV_SYSTEM_STATE represents system state received from SimConnect
@@ -155,76 +184,93 @@ type CustomActionData struct {
 	Result        uint32 `json:"result"`          // Result of the action
 }
 
+// SIMCONNECT_RECV_ACTION_CALLBACK reports the completion of an action started with SimConnect_ExecuteAction (MSFS 2024)
+type SIMCONNECT_RECV_ACTION_CALLBACK struct {
+	SIMCONNECT_RECV           // Inherits from base structure
+	SzActionID      [256]byte // ID of the executed action
+	DwRequestID     uint32    // ID of the client defined request
+}
+
+// ActionCallback represents a completed action for channel messages
+type ActionCallback struct {
+	RequestID uint32 `json:"request_id"` // ID of the original request
+	ActionID  string `json:"action_id"`  // ID of the executed action
+}
+
 type SimConnectRecvID uint32
 
 // SIMCONNECT_RECV_ID defines all possible message types that can be received from SimConnect
 const (
-	SIMCONNECT_RECV_ID_NULL                             SimConnectRecvID = iota // Null message
-	SIMCONNECT_RECV_ID_EXCEPTION                                                // Exception information
-	SIMCONNECT_RECV_ID_OPEN                                                     // Connection established
-	SIMCONNECT_RECV_ID_QUIT                                                     // Connection closed
-	SIMCONNECT_RECV_ID_EVENT                                                    // Event information
-	SIMCONNECT_RECV_ID_EVENT_OBJECT_ADDREMOVE                                   // Object added or removed
-	SIMCONNECT_RECV_ID_EVENT_FILENAME                                           // Filename event
-	SIMCONNECT_RECV_ID_EVENT_FRAME                                              // Frame event
-	SIMCONNECT_RECV_ID_SIMOBJECT_DATA                                           // SimObject data
-	SIMCONNECT_RECV_ID_SIMOBJECT_DATA_BYTYPE                                    // SimObject data by type
-	SIMCONNECT_RECV_ID_WEATHER_OBSERVATION                                      // Weather observation
-	SIMCONNECT_RECV_ID_CLOUD_STATE                                              // Cloud state
-	SIMCONNECT_RECV_ID_ASSIGNED_OBJECT_ID                                       // Assigned object ID
-	SIMCONNECT_RECV_ID_RESERVED_KEY                                             // Reserved key
-	SIMCONNECT_RECV_ID_CUSTOM_ACTION                                            // Custom action
-	SIMCONNECT_RECV_ID_SYSTEM_STATE                                             // System state
-	SIMCONNECT_RECV_ID_CLIENT_DATA                                              // Client data
-	SIMCONNECT_RECV_ID_EVENT_WEATHER_MODE                                       // Weather mode event
-	SIMCONNECT_RECV_ID_AIRPORT_LIST                                             // Airport list
-	SIMCONNECT_RECV_ID_VOR_LIST                                                 // VOR list
-	SIMCONNECT_RECV_ID_NDB_LIST                                                 // NDB list
-	SIMCONNECT_RECV_ID_WAYPOINT_LIST                                            // Waypoint list
-	SIMCONNECT_RECV_ID_EVENT_MULTIPLAYER_SERVER_STARTED                         // Multiplayer server started
-	SIMCONNECT_RECV_ID_EVENT_MULTIPLAYER_CLIENT_STARTED                         // Multiplayer client started
-	SIMCONNECT_RECV_ID_EVENT_MULTIPLAYER_SESSION_ENDED                          // Multiplayer session ended
-	SIMCONNECT_RECV_ID_EVENT_RACE_END                                           // Race end event
-	SIMCONNECT_RECV_ID_EVENT_RACE_LAP                                           // Race lap event
-	SIMCONNECT_RECV_ID_PICK                                                     // Pick event
-	SIMCONNECT_RECV_ID_EVENT_EX1                                                // Extended event 1
-	SIMCONNECT_RECV_ID_FACILITY_DATA                                            // Facility data
-	SIMCONNECT_RECV_ID_FACILITY_DATA_END                                        // Facility data end
-	SIMCONNECT_RECV_ID_FACILITY_MINIMAL_LIST                                    // Facility minimal list
-	SIMCONNECT_RECV_ID_JETWAY_DATA                                              // Jetway data
-	SIMCONNECT_RECV_ID_CONTROLLERS_LIST                                         // Controllers list
-	SIMCONNECT_RECV_ID_ACTION_CALLBACK                                          // Action callback
-	SIMCONNECT_RECV_ID_ENUMERATE_INPUT_EVENTS                                   // Enumerate input events
-	SIMCONNECT_RECV_ID_GET_INPUT_EVENT                                          // Get input event
-	SIMCONNECT_RECV_ID_SUBSCRIBE_INPUT_EVENT                                    // Subscribe to input event
-	SIMCONNECT_RECV_ID_ENUMERATE_INPUT_EVENT_PARAMS                             // Enumerate input event parameters
+	SIMCONNECT_RECV_ID_NULL                                SimConnectRecvID = iota // Null message
+	SIMCONNECT_RECV_ID_EXCEPTION                                                   // Exception information
+	SIMCONNECT_RECV_ID_OPEN                                                        // Connection established
+	SIMCONNECT_RECV_ID_QUIT                                                        // Connection closed
+	SIMCONNECT_RECV_ID_EVENT                                                       // Event information
+	SIMCONNECT_RECV_ID_EVENT_OBJECT_ADDREMOVE                                      // Object added or removed
+	SIMCONNECT_RECV_ID_EVENT_FILENAME                                              // Filename event
+	SIMCONNECT_RECV_ID_EVENT_FRAME                                                 // Frame event
+	SIMCONNECT_RECV_ID_SIMOBJECT_DATA                                              // SimObject data
+	SIMCONNECT_RECV_ID_SIMOBJECT_DATA_BYTYPE                                       // SimObject data by type
+	SIMCONNECT_RECV_ID_WEATHER_OBSERVATION                                         // Weather observation
+	SIMCONNECT_RECV_ID_CLOUD_STATE                                                 // Cloud state
+	SIMCONNECT_RECV_ID_ASSIGNED_OBJECT_ID                                          // Assigned object ID
+	SIMCONNECT_RECV_ID_RESERVED_KEY                                                // Reserved key
+	SIMCONNECT_RECV_ID_CUSTOM_ACTION                                               // Custom action
+	SIMCONNECT_RECV_ID_SYSTEM_STATE                                                // System state
+	SIMCONNECT_RECV_ID_CLIENT_DATA                                                 // Client data
+	SIMCONNECT_RECV_ID_EVENT_WEATHER_MODE                                          // Weather mode event
+	SIMCONNECT_RECV_ID_AIRPORT_LIST                                                // Airport list
+	SIMCONNECT_RECV_ID_VOR_LIST                                                    // VOR list
+	SIMCONNECT_RECV_ID_NDB_LIST                                                    // NDB list
+	SIMCONNECT_RECV_ID_WAYPOINT_LIST                                               // Waypoint list
+	SIMCONNECT_RECV_ID_EVENT_MULTIPLAYER_SERVER_STARTED                            // Multiplayer server started
+	SIMCONNECT_RECV_ID_EVENT_MULTIPLAYER_CLIENT_STARTED                            // Multiplayer client started
+	SIMCONNECT_RECV_ID_EVENT_MULTIPLAYER_SESSION_ENDED                             // Multiplayer session ended
+	SIMCONNECT_RECV_ID_EVENT_RACE_END                                              // Race end event
+	SIMCONNECT_RECV_ID_EVENT_RACE_LAP                                              // Race lap event
+	SIMCONNECT_RECV_ID_EVENT_EX1                                                   // Extended event 1
+	SIMCONNECT_RECV_ID_FACILITY_DATA                                               // Facility data
+	SIMCONNECT_RECV_ID_FACILITY_DATA_END                                           // Facility data end
+	SIMCONNECT_RECV_ID_FACILITY_MINIMAL_LIST                                       // Facility minimal list
+	SIMCONNECT_RECV_ID_JETWAY_DATA                                                 // Jetway data
+	SIMCONNECT_RECV_ID_CONTROLLERS_LIST                                            // Controllers list
+	SIMCONNECT_RECV_ID_ACTION_CALLBACK                                             // Action callback
+	SIMCONNECT_RECV_ID_ENUMERATE_INPUT_EVENTS                                      // Enumerate input events
+	SIMCONNECT_RECV_ID_GET_INPUT_EVENT                                             // Get input event
+	SIMCONNECT_RECV_ID_SUBSCRIBE_INPUT_EVENT                                       // Subscribe to input event
+	SIMCONNECT_RECV_ID_ENUMERATE_INPUT_EVENT_PARAMS                                // Enumerate input event parameters
+	SIMCONNECT_RECV_ID_ENUMERATE_SIMOBJECT_AND_LIVERY_LIST                         // SimObject and livery list (MSFS 2024)
+	SIMCONNECT_RECV_ID_FLOW_EVENT                                                  // Flow event (MSFS 2024)
 )
 
+// SIMCONNECT_RECV_ID_PICK is only defined by SDK headers built with ENABLE_SIMCONNECT_EXPERIMENTAL.
+// MSFS does not send it and numbers EVENT_EX1 directly after EVENT_RACE_LAP, so it is kept
+// outside the enumeration with a value the simulator never uses.
+const SIMCONNECT_RECV_ID_PICK SimConnectRecvID = 0x7FFFFFFF
+
 // === NEW CRITICAL EVENT STRUCTURES ===
 
 // SIMCONNECT_RECV_EVENT_OBJECT_ADDREMOVE represents object add/remove events
 // Used for tracking when AI aircraft, vehicles, or other objects are added/removed from simulation
 type SIMCONNECT_RECV_EVENT_OBJECT_ADDREMOVE struct {
-	SIMCONNECT_RECV        // Inherits from base structure
-	UEventID        uint32 // Event ID for the object add/remove event
-	DwData          uint32 // Object ID of the added/removed object
+	SIMCONNECT_RECV_EVENT        // Group, event ID and object ID (in DwData)
+	EObjType              uint32 // SIMCONNECT_SIMOBJECT_TYPE of the object
 }
 
 // ObjectAddRemoveData represents parsed object add/remove event for channel messages
 type ObjectAddRemoveData struct {
-	EventID  uint32 `json:"event_id"`  // ID of the add/remove event
-	ObjectID uint32 `json:"object_id"` // ID of the object that was added/removed
-	Action   string `json:"action"`    // "added" or "removed"
+	EventID    uint32 `json:"event_id"`    // ID of the add/remove event
+	ObjectID   uint32 `json:"object_id"`   // ID of the object that was added/removed
+	ObjectType uint32 `json:"object_type"` // SIMCONNECT_SIMOBJECT_TYPE of the object
+	Action     string `json:"action"`      // "added", "removed" or "unknown" when the event was not subscribed by name
 }
 
 // SIMCONNECT_RECV_EVENT_FILENAME represents filename-related events
 // Used for tracking flight plan loads, aircraft model changes, etc.
 type SIMCONNECT_RECV_EVENT_FILENAME struct {
-	SIMCONNECT_RECV           // Inherits from base structure
-	UEventID        uint32    // Event ID for the filename event
-	DwFlags         uint32    // Flags associated with the filename event
-	DwGroupID       uint32    // Group ID for the event
-	SzFileName      [260]byte // Filename associated with the event
+	SIMCONNECT_RECV_EVENT           // Group, event ID and data
+	SzFileName            [260]byte // Filename associated with the event
+	DwFlags               uint32    // Flags associated with the filename event
 }
 
 // FilenameEventData represents parsed filename event for channel messages
@@ -238,34 +284,44 @@ type FilenameEventData struct {
 // SIMCONNECT_RECV_EVENT_FRAME represents frame timing events
 // Used for frame-based notifications and timing-sensitive operations
 type SIMCONNECT_RECV_EVENT_FRAME struct {
-	SIMCONNECT_RECV        // Inherits from base structure
-	DwFrameRate     uint32 // Current frame rate
-	DwSimSpeed      uint32 // Current simulation speed multiplier
+	SIMCONNECT_RECV_EVENT         // Group, event ID and data
+	FFrameRate            float32 // Current frame rate
+	FSimSpeed             float32 // Current simulation speed multiplier
 }
 
 // FrameEventData represents parsed frame event for channel messages
 type FrameEventData struct {
-	FrameRate uint32 `json:"frame_rate"` // Current frame rate (frames per second)
-	SimSpeed  uint32 `json:"sim_speed"`  // Simulation speed multiplier
+	EventID   uint32  `json:"event_id"`   // ID of the frame event
+	FrameRate float32 `json:"frame_rate"` // Current frame rate (frames per second)
+	SimSpeed  float32 `json:"sim_speed"`  // Simulation speed multiplier
 }
 
 // SIMCONNECT_RECV_FACILITY_DATA represents facility (airport/navigation) data
 // Used for receiving information about airports, VORs, NDBs, etc.
+// Each message carries one record of a facility definition scope; nested scopes
+// (e.g. RUNWAY inside AIRPORT) arrive as separate messages linked by parent ID.
 type SIMCONNECT_RECV_FACILITY_DATA struct {
-	SIMCONNECT_RECV        // Inherits from base structure
-	DwRequestID     uint32 // ID of the original request
-	DwArraySize     uint32 // Number of facilities in the data
-	DwEntryNumber   uint32 // Index of this entry (1-based)
-	DwOutOf         uint32 // Total number of entries
+	SIMCONNECT_RECV                        // Inherits from base structure
+	UserRequestId         uint32           // ID of the client defined request
+	UniqueRequestId       uint32           // Unique ID of this record within the request
+	ParentUniqueRequestId uint32           // Unique ID of the parent record (0 for the root)
+	Type                  FacilityDataType // Type of the record
+	IsListItem            uint32           // 1 if the record is an item of a list
+	ItemIndex             uint32           // Index of the item in the list
+	ListSize              uint32           // Number of items in the list
+	Data                  uint32           // Start of data array (actual data follows)
 }
 
 // FacilityData represents parsed facility data for channel messages
 type FacilityData struct {
-	RequestID    uint32      `json:"request_id"`    // ID of the original request
-	ArraySize    uint32      `json:"array_size"`    // Number of facilities
-	EntryNumber  uint32      `json:"entry_number"`  // Index of this entry
-	TotalEntries uint32      `json:"total_entries"` // Total number of entries
-	Data         interface{} `json:"data"`          // The actual facility data
+	RequestID       uint32           `json:"request_id"`        // ID of the original request
+	UniqueRequestID uint32           `json:"unique_request_id"` // Unique ID of this record
+	ParentID        uint32           `json:"parent_id"`         // Unique ID of the parent record
+	Type            FacilityDataType `json:"type"`              // Type of the record
+	IsListItem      bool             `json:"is_list_item"`      // Whether the record is a list item
+	ItemIndex       uint32           `json:"item_index"`        // Index of the item in its list
+	ListSize        uint32           `json:"list_size"`         // Number of items in the list
+	Data            []byte           `json:"data"`              // Raw field values, decoded by pkg/facilities
 }
 
 // SIMCONNECT_RECV_PICK represents mouse pick events in the 3D world
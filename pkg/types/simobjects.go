@@ -0,0 +1,64 @@
+package types
+
+// SimObjectType represents SIMCONNECT_SIMOBJECT_TYPE
+type SimObjectType uint32
+
+const (
+	SIMCONNECT_SIMOBJECT_TYPE_USER       SimObjectType = iota // The user's aircraft
+	SIMCONNECT_SIMOBJECT_TYPE_ALL                             // All object types
+	SIMCONNECT_SIMOBJECT_TYPE_AIRCRAFT                        // Airplanes
+	SIMCONNECT_SIMOBJECT_TYPE_HELICOPTER                      // Helicopters
+	SIMCONNECT_SIMOBJECT_TYPE_BOAT                            // Boats
+	SIMCONNECT_SIMOBJECT_TYPE_GROUND                          // Ground vehicles
+)
+
+func (t SimObjectType) String() string {
+	switch t {
+	case SIMCONNECT_SIMOBJECT_TYPE_USER:
+		return "USER"
+	case SIMCONNECT_SIMOBJECT_TYPE_ALL:
+		return "ALL"
+	case SIMCONNECT_SIMOBJECT_TYPE_AIRCRAFT:
+		return "AIRCRAFT"
+	case SIMCONNECT_SIMOBJECT_TYPE_HELICOPTER:
+		return "HELICOPTER"
+	case SIMCONNECT_SIMOBJECT_TYPE_BOAT:
+		return "BOAT"
+	case SIMCONNECT_SIMOBJECT_TYPE_GROUND:
+		return "GROUND"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// SimObjectLivery represents SIMCONNECT_ENUMERATE_SIMOBJECT_LIVERY, an installed model and one of its liveries
+type SimObjectLivery struct {
+	Title  string `json:"title"`  // Title of the SimObject, as used by AICreate* calls
+	Livery string `json:"livery"` // Livery name
+}
+
+// SimObjectLiveryList is one page of SIMCONNECT_RECV_ENUMERATE_SIMOBJECT_AND_LIVERY_LIST
+type SimObjectLiveryList struct {
+	RequestID   uint32            `json:"request_id"`
+	EntryNumber uint32            `json:"entry_number"` // Index of this page
+	OutOf       uint32            `json:"out_of"`       // Total number of pages
+	Liveries    []SimObjectLivery `json:"liveries"`
+}
+
+// IsLastPage reports whether this page completes the enumeration
+func (l *SimObjectLiveryList) IsLastPage() bool {
+	return l.OutOf == 0 || l.EntryNumber+1 >= l.OutOf
+}
+
+// Titles returns the distinct SimObject titles of the list in order of first appearance
+func (l *SimObjectLiveryList) Titles() []string {
+	seen := make(map[string]bool)
+	var titles []string
+	for _, item := range l.Liveries {
+		if !seen[item.Title] {
+			seen[item.Title] = true
+			titles = append(titles, item.Title)
+		}
+	}
+	return titles
+}
@@ -0,0 +1,396 @@
+package types
+
+// SystemStateName is a state that can be queried with SimConnect_RequestSystemState
+type SystemStateName string
+
+// SIMCONNECT system states
+const (
+	SIMCONNECT_SYSTEM_STATE_AIRCRAFT_LOADED SystemStateName = "AircraftLoaded" // Full path of the loaded aircraft .CFG (string)
+	SIMCONNECT_SYSTEM_STATE_DIALOG_MODE     SystemStateName = "DialogMode"     // 1 when a dialog is open (integer)
+	SIMCONNECT_SYSTEM_STATE_FLIGHT_LOADED   SystemStateName = "FlightLoaded"   // Full path of the loaded .FLT (string)
+	SIMCONNECT_SYSTEM_STATE_FLIGHT_PLAN     SystemStateName = "FlightPlan"     // Full path of the active .PLN (string)
+	SIMCONNECT_SYSTEM_STATE_SIM             SystemStateName = "Sim"            // 1 when the user is in control of the aircraft (integer)
+)
+
+// Bool interprets an integer system state (DialogMode, Sim)
+func (s *SystemStateData) Bool() bool {
+	return s.IntegerValue != 0
+}
+
+// Path returns the file path of a string system state (AircraftLoaded, FlightLoaded, FlightPlan)
+func (s *SystemStateData) Path() string {
+	return s.StringValue
+}
+
+// SystemEventName is a system event that can be passed to SimConnect_SubscribeToSystemEvent
+type SystemEventName string
+
+// SIMCONNECT system events
+const (
+	SIMCONNECT_SYSTEM_EVENT_1SEC                       SystemEventName = "1sec"
+	SIMCONNECT_SYSTEM_EVENT_4SEC                       SystemEventName = "4sec"
+	SIMCONNECT_SYSTEM_EVENT_6HZ                        SystemEventName = "6Hz"
+	SIMCONNECT_SYSTEM_EVENT_AIRCRAFT_LOADED            SystemEventName = "AircraftLoaded"
+	SIMCONNECT_SYSTEM_EVENT_CRASHED                    SystemEventName = "Crashed"
+	SIMCONNECT_SYSTEM_EVENT_CRASH_RESET                SystemEventName = "CrashReset"
+	SIMCONNECT_SYSTEM_EVENT_CUSTOM_MISSION_ACTION      SystemEventName = "CustomMissionActionExecuted"
+	SIMCONNECT_SYSTEM_EVENT_FLIGHT_LOADED              SystemEventName = "FlightLoaded"
+	SIMCONNECT_SYSTEM_EVENT_FLIGHT_SAVED               SystemEventName = "FlightSaved"
+	SIMCONNECT_SYSTEM_EVENT_FLIGHT_PLAN_ACTIVATED      SystemEventName = "FlightPlanActivated"
+	SIMCONNECT_SYSTEM_EVENT_FLIGHT_PLAN_DEACTIVATED    SystemEventName = "FlightPlanDeactivated"
+	SIMCONNECT_SYSTEM_EVENT_FRAME                      SystemEventName = "Frame"
+	SIMCONNECT_SYSTEM_EVENT_MULTIPLAYER_CLIENT_STARTED SystemEventName = "MultiplayerClientStarted"
+	SIMCONNECT_SYSTEM_EVENT_MULTIPLAYER_SERVER_STARTED SystemEventName = "MultiplayerServerStarted"
+	SIMCONNECT_SYSTEM_EVENT_MULTIPLAYER_SESSION_ENDED  SystemEventName = "MultiplayerSessionEnded"
+	SIMCONNECT_SYSTEM_EVENT_OBJECT_ADDED               SystemEventName = "ObjectAdded"
+	SIMCONNECT_SYSTEM_EVENT_OBJECT_REMOVED             SystemEventName = "ObjectRemoved"
+	SIMCONNECT_SYSTEM_EVENT_PAUSE                      SystemEventName = "Pause"
+	SIMCONNECT_SYSTEM_EVENT_PAUSE_EX1                  SystemEventName = "Pause_EX1"
+	SIMCONNECT_SYSTEM_EVENT_PAUSED                     SystemEventName = "Paused"
+	SIMCONNECT_SYSTEM_EVENT_PAUSE_FRAME                SystemEventName = "PauseFrame"
+	SIMCONNECT_SYSTEM_EVENT_POSITION_CHANGED           SystemEventName = "PositionChanged"
+	SIMCONNECT_SYSTEM_EVENT_RACE_END                   SystemEventName = "RaceEnd"
+	SIMCONNECT_SYSTEM_EVENT_RACE_LAP                   SystemEventName = "RaceLap"
+	SIMCONNECT_SYSTEM_EVENT_SIM                        SystemEventName = "Sim"
+	SIMCONNECT_SYSTEM_EVENT_SIM_START                  SystemEventName = "SimStart"
+	SIMCONNECT_SYSTEM_EVENT_SIM_STOP                   SystemEventName = "SimStop"
+	SIMCONNECT_SYSTEM_EVENT_SOUND                      SystemEventName = "Sound"
+	SIMCONNECT_SYSTEM_EVENT_UNPAUSED                   SystemEventName = "Unpaused"
+	SIMCONNECT_SYSTEM_EVENT_VIEW                       SystemEventName = "View"
+	SIMCONNECT_SYSTEM_EVENT_WEATHER_MODE_CHANGED       SystemEventName = "WeatherModeChanged"
+)
+
+// SystemEventPayload describes how a system event delivers its value
+type SystemEventPayload int
+
+const (
+	SystemEventPayloadNone        SystemEventPayload = iota // EVENT without meaningful data
+	SystemEventPayloadState                                 // EVENT with 0/1 in DwData, decoded as bool
+	SystemEventPayloadPause                                 // EVENT with a pause state, decoded as PauseState
+	SystemEventPayloadView                                  // EVENT with view flags in DwData, decoded as ViewState
+	SystemEventPayloadCrash                                 // EVENT without data, decoded as CrashEvent
+	SystemEventPayloadFilename                              // EVENT_FILENAME, decoded as *FilenameEventData
+	SystemEventPayloadFrame                                 // EVENT_FRAME, decoded as *FrameEventData
+	SystemEventPayloadObject                                // EVENT_OBJECT_ADDREMOVE, decoded as *ObjectAddRemoveData
+	SystemEventPayloadMultiplayer                           // EVENT_MULTIPLAYER_*, decoded as MultiplayerEvent
+	SystemEventPayloadRace                                  // EVENT_RACE_END and EVENT_RACE_LAP, decoded as *RaceEventData
+	SystemEventPayloadWeatherMode                           // EVENT_WEATHER_MODE with the mode in DwData, decoded as WeatherMode
+)
+
+// SystemEventInfo documents a system event
+type SystemEventInfo struct {
+	Name        SystemEventName
+	Payload     SystemEventPayload
+	Description string
+}
+
+var systemEventCatalogue = []SystemEventInfo{
+	{SIMCONNECT_SYSTEM_EVENT_1SEC, SystemEventPayloadNone, "Once every second"},
+	{SIMCONNECT_SYSTEM_EVENT_4SEC, SystemEventPayloadNone, "Once every four seconds"},
+	{SIMCONNECT_SYSTEM_EVENT_6HZ, SystemEventPayloadNone, "Six times per second"},
+	{SIMCONNECT_SYSTEM_EVENT_AIRCRAFT_LOADED, SystemEventPayloadFilename, "Aircraft flight dynamics file changed; carries the .AIR path"},
+	{SIMCONNECT_SYSTEM_EVENT_CRASHED, SystemEventPayloadCrash, "The user aircraft crashed"},
+	{SIMCONNECT_SYSTEM_EVENT_CRASH_RESET, SystemEventPayloadCrash, "The crash cut-scene has completed"},
+	{SIMCONNECT_SYSTEM_EVENT_CUSTOM_MISSION_ACTION, SystemEventPayloadNone, "A mission action has been executed"},
+	{SIMCONNECT_SYSTEM_EVENT_FLIGHT_LOADED, SystemEventPayloadFilename, "A flight has been loaded; carries the .FLT path"},
+	{SIMCONNECT_SYSTEM_EVENT_FLIGHT_SAVED, SystemEventPayloadFilename, "A flight has been saved; carries the .FLT path"},
+	{SIMCONNECT_SYSTEM_EVENT_FLIGHT_PLAN_ACTIVATED, SystemEventPayloadFilename, "A flight plan has been activated; carries the .PLN path"},
+	{SIMCONNECT_SYSTEM_EVENT_FLIGHT_PLAN_DEACTIVATED, SystemEventPayloadNone, "The active flight plan has been deactivated"},
+	{SIMCONNECT_SYSTEM_EVENT_FRAME, SystemEventPayloadFrame, "Every visual frame; carries frame rate and simulation speed"},
+	{SIMCONNECT_SYSTEM_EVENT_MULTIPLAYER_CLIENT_STARTED, SystemEventPayloadMultiplayer, "The user joined a multiplayer session as a client"},
+	{SIMCONNECT_SYSTEM_EVENT_MULTIPLAYER_SERVER_STARTED, SystemEventPayloadMultiplayer, "The user started hosting a multiplayer session"},
+	{SIMCONNECT_SYSTEM_EVENT_MULTIPLAYER_SESSION_ENDED, SystemEventPayloadMultiplayer, "The multiplayer session ended"},
+	{SIMCONNECT_SYSTEM_EVENT_OBJECT_ADDED, SystemEventPayloadObject, "An AI object has been added"},
+	{SIMCONNECT_SYSTEM_EVENT_OBJECT_REMOVED, SystemEventPayloadObject, "An AI object has been removed"},
+	{SIMCONNECT_SYSTEM_EVENT_PAUSE, SystemEventPayloadPause, "Pause toggled; 1 paused, 0 unpaused"},
+	{SIMCONNECT_SYSTEM_EVENT_PAUSE_EX1, SystemEventPayloadPause, "Pause state changed; carries PAUSE_STATE_FLAG_* bits"},
+	{SIMCONNECT_SYSTEM_EVENT_PAUSED, SystemEventPayloadPause, "The simulator has been paused"},
+	{SIMCONNECT_SYSTEM_EVENT_PAUSE_FRAME, SystemEventPayloadFrame, "Every visual frame while paused"},
+	{SIMCONNECT_SYSTEM_EVENT_POSITION_CHANGED, SystemEventPayloadNone, "The user changed the aircraft position through a dialog"},
+	{SIMCONNECT_SYSTEM_EVENT_RACE_END, SystemEventPayloadRace, "A racer finished a multiplayer race; carries the race result"},
+	{SIMCONNECT_SYSTEM_EVENT_RACE_LAP, SystemEventPayloadRace, "A racer completed a lap of a multiplayer race; carries the race result"},
+	{SIMCONNECT_SYSTEM_EVENT_SIM, SystemEventPayloadState, "Simulation running state; 1 running, 0 stopped"},
+	{SIMCONNECT_SYSTEM_EVENT_SIM_START, SystemEventPayloadState, "The user is in control of the aircraft"},
+	{SIMCONNECT_SYSTEM_EVENT_SIM_STOP, SystemEventPayloadState, "The user is no longer in control of the aircraft"},
+	{SIMCONNECT_SYSTEM_EVENT_SOUND, SystemEventPayloadState, "Master sound toggled; 1 on, 0 off"},
+	{SIMCONNECT_SYSTEM_EVENT_UNPAUSED, SystemEventPayloadPause, "The simulator has been unpaused"},
+	{SIMCONNECT_SYSTEM_EVENT_VIEW, SystemEventPayloadView, "The user aircraft view changed; carries VIEW_SYSTEM_EVENT_DATA_* flags"},
+	{SIMCONNECT_SYSTEM_EVENT_WEATHER_MODE_CHANGED, SystemEventPayloadWeatherMode, "The weather mode changed; carries the SIMCONNECT_WEATHER_MODE"},
+}
+
+// SystemEventCatalogue returns all documented system events
+func SystemEventCatalogue() []SystemEventInfo {
+	out := make([]SystemEventInfo, len(systemEventCatalogue))
+	copy(out, systemEventCatalogue)
+	return out
+}
+
+// LookupSystemEvent returns the catalogue entry of a system event
+func LookupSystemEvent(name SystemEventName) (SystemEventInfo, bool) {
+	for _, info := range systemEventCatalogue {
+		if info.Name == name {
+			return info, true
+		}
+	}
+	return SystemEventInfo{}, false
+}
+
+// PauseState holds the SIMCONNECT_PAUSE_STATE_FLAG_* bits of a pause event
+type PauseState uint32
+
+// SIMCONNECT_PAUSE_STATE_FLAG defines the pause state bits of Pause_EX1
+const (
+	PAUSE_STATE_FLAG_OFF              PauseState = 0x00 // No pause
+	PAUSE_STATE_FLAG_PAUSE            PauseState = 0x01 // Full pause
+	PAUSE_STATE_FLAG_PAUSE_WITH_SOUND PauseState = 0x02 // Legacy pause that keeps sound playing
+	PAUSE_STATE_FLAG_ACTIVE_PAUSE     PauseState = 0x04 // Active pause
+	PAUSE_STATE_FLAG_SIM_PAUSE        PauseState = 0x08 // Simulation paused, traffic and multiplayer keep running
+)
+
+// IsPaused reports whether any pause bit is set
+func (p PauseState) IsPaused() bool {
+	return p != PAUSE_STATE_FLAG_OFF
+}
+
+// Has reports whether flag is set
+func (p PauseState) Has(flag PauseState) bool {
+	return p&flag != 0
+}
+
+// ViewState holds the SIMCONNECT_VIEW_SYSTEM_EVENT_DATA_* bits of a View event
+type ViewState uint32
+
+// SIMCONNECT_VIEW_SYSTEM_EVENT_DATA defines the view bits of the View event
+const (
+	VIEW_SYSTEM_EVENT_DATA_COCKPIT_2D      ViewState = 0x01 // 2D panels in cockpit view
+	VIEW_SYSTEM_EVENT_DATA_COCKPIT_VIRTUAL ViewState = 0x02 // Virtual (3D) panels in cockpit view
+	VIEW_SYSTEM_EVENT_DATA_ORTHOGONAL      ViewState = 0x04 // Orthogonal (map) view
+)
+
+// IsCockpit reports whether a cockpit view is active
+func (v ViewState) IsCockpit() bool {
+	return v&(VIEW_SYSTEM_EVENT_DATA_COCKPIT_2D|VIEW_SYSTEM_EVENT_DATA_COCKPIT_VIRTUAL) != 0
+}
+
+// CrashEvent is the value of the Crashed and CrashReset events
+type CrashEvent struct {
+	Reset bool `json:"reset"` // false for Crashed, true for CrashReset
+}
+
+// MultiplayerEvent is the value of the MultiplayerClientStarted, MultiplayerServerStarted and MultiplayerSessionEnded events
+type MultiplayerEvent struct {
+	Started bool `json:"started"` // false for MultiplayerSessionEnded
+	Host    bool `json:"host"`    // true for MultiplayerServerStarted
+}
+
+// WeatherMode is the SIMCONNECT_WEATHER_MODE of a WeatherModeChanged event
+type WeatherMode uint32
+
+// SIMCONNECT_WEATHER_MODE defines the weather modes
+const (
+	SIMCONNECT_WEATHER_MODE_THEME  WeatherMode = iota // Weather theme
+	SIMCONNECT_WEATHER_MODE_RWW                       // Real-world weather
+	SIMCONNECT_WEATHER_MODE_CUSTOM                    // Custom weather
+	SIMCONNECT_WEATHER_MODE_GLOBAL                    // Global weather
+)
+
+// String returns a readable name of the weather mode
+func (m WeatherMode) String() string {
+	switch m {
+	case SIMCONNECT_WEATHER_MODE_THEME:
+		return "theme"
+	case SIMCONNECT_WEATHER_MODE_RWW:
+		return "real-world"
+	case SIMCONNECT_WEATHER_MODE_CUSTOM:
+		return "custom"
+	case SIMCONNECT_WEATHER_MODE_GLOBAL:
+		return "global"
+	default:
+		return "unknown"
+	}
+}
+
+// RaceResult is the SIMCONNECT_DATA_RACE_RESULT of a racer
+type RaceResult struct {
+	NumberOfRacers uint32  `json:"number_of_racers"` // Racers in the session
+	MissionGUID    string  `json:"mission_guid"`     // Mission GUID, formatted as {XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX}
+	PlayerName     string  `json:"player_name"`      // Name of the racer
+	SessionType    string  `json:"session_type"`     // Multiplayer session type
+	Aircraft       string  `json:"aircraft"`         // Aircraft flown by the racer
+	PlayerRole     string  `json:"player_role"`      // Role of the racer in the session
+	TotalTime      float64 `json:"total_time"`       // Total time, seconds
+	PenaltyTime    float64 `json:"penalty_time"`     // Penalty time, seconds
+	IsDisqualified bool    `json:"is_disqualified"`  // The racer was disqualified
+}
+
+// RaceEventData represents parsed EVENT_RACE_END and EVENT_RACE_LAP messages
+type RaceEventData struct {
+	EventID     uint32           `json:"event_id"`     // ID of the subscribed event
+	GroupID     uint32           `json:"group_id"`     // Group ID
+	EventType   SimConnectRecvID `json:"event_type"`   // EVENT_RACE_END or EVENT_RACE_LAP
+	RacerNumber uint32           `json:"racer_number"` // Index of the racer (RaceEnd)
+	LapIndex    uint32           `json:"lap_index"`    // Index of the completed lap (RaceLap)
+	Result      RaceResult       `json:"result"`       // Result of the racer
+}
+
+// SystemEvent is a system event decoded according to the catalogue
+type SystemEvent struct {
+	Name    SystemEventName    `json:"name"`
+	EventID uint32             `json:"event_id"`
+	Payload SystemEventPayload `json:"payload"`
+	Value   any                `json:"value,omitempty"` // bool, PauseState, ViewState, CrashEvent, MultiplayerEvent, WeatherMode, *FilenameEventData, *FrameEventData, *ObjectAddRemoveData or *RaceEventData
+}
+
+// DecodeSystemEvent decodes the DwData of a plain EVENT message for a named system event
+func DecodeSystemEvent(name SystemEventName, eventID uint32, data uint32) *SystemEvent {
+	info, _ := LookupSystemEvent(name)
+	ev := &SystemEvent{Name: name, EventID: eventID, Payload: info.Payload}
+
+	switch info.Payload {
+	case SystemEventPayloadState:
+		switch name {
+		case SIMCONNECT_SYSTEM_EVENT_SIM_START:
+			ev.Value = true
+		case SIMCONNECT_SYSTEM_EVENT_SIM_STOP:
+			ev.Value = false
+		default:
+			ev.Value = data != 0
+		}
+	case SystemEventPayloadPause:
+		switch name {
+		case SIMCONNECT_SYSTEM_EVENT_PAUSED:
+			ev.Value = PAUSE_STATE_FLAG_PAUSE
+		case SIMCONNECT_SYSTEM_EVENT_UNPAUSED:
+			ev.Value = PAUSE_STATE_FLAG_OFF
+		case SIMCONNECT_SYSTEM_EVENT_PAUSE:
+			if data != 0 {
+				ev.Value = PAUSE_STATE_FLAG_PAUSE
+			} else {
+				ev.Value = PAUSE_STATE_FLAG_OFF
+			}
+		default:
+			ev.Value = PauseState(data)
+		}
+	case SystemEventPayloadView:
+		ev.Value = ViewState(data)
+	case SystemEventPayloadCrash:
+		ev.Value = CrashEvent{Reset: name == SIMCONNECT_SYSTEM_EVENT_CRASH_RESET}
+	case SystemEventPayloadMultiplayer:
+		ev.Value = MultiplayerEvent{
+			Started: name != SIMCONNECT_SYSTEM_EVENT_MULTIPLAYER_SESSION_ENDED,
+			Host:    name == SIMCONNECT_SYSTEM_EVENT_MULTIPLAYER_SERVER_STARTED,
+		}
+	case SystemEventPayloadWeatherMode:
+		ev.Value = WeatherMode(data)
+	}
+	return ev
+}
+
+// State returns the value of Sim, SimStart, SimStop and Sound events
+func (e *SystemEvent) State() (bool, bool) {
+	v, ok := e.Value.(bool)
+	return v, ok
+}
+
+// PauseState returns the value of Pause, Pause_EX1, Paused and Unpaused events
+func (e *SystemEvent) PauseState() (PauseState, bool) {
+	v, ok := e.Value.(PauseState)
+	return v, ok
+}
+
+// ViewState returns the value of View events
+func (e *SystemEvent) ViewState() (ViewState, bool) {
+	v, ok := e.Value.(ViewState)
+	return v, ok
+}
+
+// Crash returns the value of Crashed and CrashReset events
+func (e *SystemEvent) Crash() (CrashEvent, bool) {
+	v, ok := e.Value.(CrashEvent)
+	return v, ok
+}
+
+// Multiplayer returns the value of MultiplayerClientStarted, MultiplayerServerStarted and MultiplayerSessionEnded events
+func (e *SystemEvent) Multiplayer() (MultiplayerEvent, bool) {
+	v, ok := e.Value.(MultiplayerEvent)
+	return v, ok
+}
+
+// WeatherMode returns the value of WeatherModeChanged events
+func (e *SystemEvent) WeatherMode() (WeatherMode, bool) {
+	v, ok := e.Value.(WeatherMode)
+	return v, ok
+}
+
+// Race returns the value of RaceEnd and RaceLap events
+func (e *SystemEvent) Race() (*RaceEventData, bool) {
+	v, ok := e.Value.(*RaceEventData)
+	return v, ok
+}
+
+// Filename returns the value of AircraftLoaded, FlightLoaded, FlightSaved and FlightPlanActivated events
+func (e *SystemEvent) Filename() (*FilenameEventData, bool) {
+	v, ok := e.Value.(*FilenameEventData)
+	return v, ok
+}
+
+// Frame returns the value of Frame and PauseFrame events
+func (e *SystemEvent) Frame() (*FrameEventData, bool) {
+	v, ok := e.Value.(*FrameEventData)
+	return v, ok
+}
+
+// Object returns the value of ObjectAdded and ObjectRemoved events
+func (e *SystemEvent) Object() (*ObjectAddRemoveData, bool) {
+	v, ok := e.Value.(*ObjectAddRemoveData)
+	return v, ok
+}
+
+// SimConnectState is the SIMCONNECT_STATE passed to SetSystemEventState
+type SimConnectState uint32
+
+// SIMCONNECT_STATE defines on/off states
+const (
+	SIMCONNECT_STATE_OFF SimConnectState = iota // Off
+	SIMCONNECT_STATE_ON                         // On
+)
+
+// SystemEventStatus is the bookkeeping state of a system event subscription
+type SystemEventStatus int
+
+const (
+	SystemEventActive       SystemEventStatus = iota // Subscribed and delivering events
+	SystemEventInactive                              // Subscribed but turned off with SetSystemEventState
+	SystemEventUnsubscribed                          // Removed with UnsubscribeFromSystemEvent
+)
+
+// String returns a readable name of the status
+func (s SystemEventStatus) String() string {
+	switch s {
+	case SystemEventActive:
+		return "active"
+	case SystemEventInactive:
+		return "inactive"
+	case SystemEventUnsubscribed:
+		return "unsubscribed"
+	default:
+		return "unknown"
+	}
+}
+
+// SystemEventSubscription describes a system event subscription known to the Engine
+type SystemEventSubscription struct {
+	EventID uint32            `json:"event_id"`
+	Name    SystemEventName   `json:"name"`
+	Status  SystemEventStatus `json:"status"`
+}
+
+// IsSystemEvent checks if a message carries a decoded system event and returns it
+func IsSystemEvent(msg any) (*SystemEvent, bool) {
+	if msgMap, ok := msg.(map[string]any); ok {
+		if event, ok := msgMap["system_event"].(*SystemEvent); ok {
+			return event, true
+		}
+	}
+	return nil, false
+}
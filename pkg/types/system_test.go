@@ -0,0 +1,49 @@
+package types
+
+import "testing"
+
+func TestDecodeSystemEvent(t *testing.T) {
+	tests := []struct {
+		name SystemEventName
+		data uint32
+		want any
+	}{
+		{SIMCONNECT_SYSTEM_EVENT_SIM_START, 0, true},
+		{SIMCONNECT_SYSTEM_EVENT_SIM_STOP, 1, false},
+		{SIMCONNECT_SYSTEM_EVENT_PAUSE_EX1, 0x0C, PAUSE_STATE_FLAG_ACTIVE_PAUSE | PAUSE_STATE_FLAG_SIM_PAUSE},
+		{SIMCONNECT_SYSTEM_EVENT_PAUSE, 1, PAUSE_STATE_FLAG_PAUSE},
+		{SIMCONNECT_SYSTEM_EVENT_CRASH_RESET, 0, CrashEvent{Reset: true}},
+		{SIMCONNECT_SYSTEM_EVENT_MULTIPLAYER_CLIENT_STARTED, 0, MultiplayerEvent{Started: true}},
+		{SIMCONNECT_SYSTEM_EVENT_MULTIPLAYER_SERVER_STARTED, 0, MultiplayerEvent{Started: true, Host: true}},
+		{SIMCONNECT_SYSTEM_EVENT_MULTIPLAYER_SESSION_ENDED, 0, MultiplayerEvent{}},
+		{SIMCONNECT_SYSTEM_EVENT_WEATHER_MODE_CHANGED, 1, SIMCONNECT_WEATHER_MODE_RWW},
+		{SIMCONNECT_SYSTEM_EVENT_RACE_LAP, 3, nil}, // The client passes the parsed *RaceEventData
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.name), func(t *testing.T) {
+			ev := DecodeSystemEvent(tt.name, 42, tt.data)
+			if ev.Name != tt.name || ev.EventID != 42 {
+				t.Errorf("event = %+v", ev)
+			}
+			if ev.Value != tt.want {
+				t.Errorf("Value = %#v, want %#v", ev.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestSystemEventCatalogue(t *testing.T) {
+	seen := make(map[SystemEventName]bool)
+	for _, info := range SystemEventCatalogue() {
+		if seen[info.Name] {
+			t.Errorf("%s catalogued twice", info.Name)
+		}
+		seen[info.Name] = true
+		if info.Description == "" {
+			t.Errorf("%s has no description", info.Name)
+		}
+	}
+	if info, ok := LookupSystemEvent("RaceEnd"); !ok || info.Payload != SystemEventPayloadRace {
+		t.Errorf("RaceEnd = %+v, %v", info, ok)
+	}
+}